@@ -7,15 +7,12 @@ require (
 	github.com/VTGare/gumi v0.2.2
 	github.com/VTGare/pixiv v0.0.6
 	github.com/antchfx/xpath v1.1.10 // indirect
-	github.com/bwmarrin/discordgo v0.22.0
+	github.com/bwmarrin/discordgo v0.27.1
 	github.com/disintegration/gift v1.2.1
 	github.com/gocolly/colly/v2 v2.1.0
 	github.com/sirupsen/logrus v1.7.0
 	github.com/valyala/fasthttp v1.16.0
 	go.mongodb.org/mongo-driver v1.4.2
-	golang.org/x/crypto v0.0.0-20201012173705-84dcc777aaee // indirect
 	golang.org/x/image v0.0.0-20200927104501-e162460cd6b5
-	golang.org/x/net v0.0.0-20201010224723-4f7140c49acb // indirect
-	golang.org/x/sys v0.0.0-20201016160150-f659759dc4ca // indirect
 	google.golang.org/protobuf v1.25.0 // indirect
 )
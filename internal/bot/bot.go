@@ -1,13 +1,14 @@
 package bot
 
 import (
-	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/VTGare/boe-tea-go/internal/commands"
 	"github.com/VTGare/boe-tea-go/internal/database"
+	"github.com/VTGare/boe-tea-go/internal/dispatcher"
+	"github.com/VTGare/boe-tea-go/internal/locale"
 	"github.com/VTGare/boe-tea-go/internal/repost"
 	"github.com/VTGare/boe-tea-go/utils"
 	"github.com/bwmarrin/discordgo"
@@ -49,6 +50,10 @@ func NewBot(token string) (*Bot, error) {
 	dg.AddHandler(bot.messageDeleted)
 	dg.AddHandler(bot.guildCreated)
 	dg.AddHandler(bot.guildDeleted)
+	dg.AddHandler(bot.interactionCreated)
+	commands.LocalizeErrors()
+	dispatcher.Default.Locales = database.Locales
+	dispatcher.Default.Register(dg)
 	dg.Identify.Intents = discordgo.MakeIntent(discordgo.IntentsAllWithoutPrivileged)
 
 	BoeTea = bot
@@ -59,17 +64,26 @@ func (b *Bot) onReady(s *discordgo.Session, e *discordgo.Ready) {
 	botMention = "<@!" + e.User.ID + ">"
 	log.Infoln(e.User.String(), "is ready.")
 	log.Infof("Connected to %v guilds!", len(e.Guilds))
+
+	if err := commands.RegisterSlashCommands(s); err != nil {
+		log.Warnf("RegisterSlashCommands(): %v", err)
+	}
+}
+
+func (b *Bot) interactionCreated(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	commands.HandleInteraction(s, i)
 }
 
 func handleError(s *discordgo.Session, m *discordgo.MessageCreate, err error) {
 	if err != nil {
 		log.Errorf("An error occured: %v", err)
+		locales := database.Locales(m.GuildID, m.Author.ID)
 		embed := &discordgo.MessageEmbed{
-			Title: "Oops, something went wrong!",
+			Title: locale.Get(locales, "error.title"),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{
 				URL: utils.DefaultEmbedImage,
 			},
-			Description: fmt.Sprintf("***Error message:***\n%v\n\nPlease contact bot's author using bt!feedback command or directly at VTGare#3599 if you can't understand the error.", err),
+			Description: locale.Get(locales, "error.description", err),
 			Color:       utils.EmbedColor,
 			Timestamp:   utils.EmbedTimestamp(),
 		}
@@ -87,9 +101,8 @@ func (b *Bot) prefixless(s *discordgo.Session, m *discordgo.MessageCreate) error
 		log.Warnln("art.Post():", err)
 	}
 
-	if user := database.DB.FindUser(m.Author.ID); user != nil {
-		channels := user.Channels(m.ChannelID)
-		err := art.Crosspost(s, channels)
+	if targets := database.CrosspostTargets(m.GuildID, m.Author.ID, m.ChannelID); len(targets) > 0 {
+		err := art.Crosspost(s, targets)
 		if err != nil {
 			log.Warnln("art.Crosspost():", err)
 		}
@@ -98,7 +111,8 @@ func (b *Bot) prefixless(s *discordgo.Session, m *discordgo.MessageCreate) error
 }
 
 func (b *Bot) messageCreated(s *discordgo.Session, m *discordgo.MessageCreate) {
-	if m.Author.Bot {
+	//webhook cross-posts look like user messages and would be cross-posted again in mesh groups
+	if m.Author.Bot || m.WebhookID != "" {
 		return
 	}
 
@@ -132,6 +146,10 @@ func (b *Bot) reactCreated(s *discordgo.Session, r *discordgo.MessageReactionAdd
 
 				s.MessageReactionRemove(cache.SentMessage.ChannelID, cache.SentMessage.ID, "🔄", r.UserID)
 			case "❌":
+				if repost.DeleteFamily(s, cache.OriginalMessage.ID) {
+					return
+				}
+
 				err := s.ChannelMessageDelete(cache.SentMessage.ChannelID, cache.SentMessage.ID)
 				if err != nil {
 					log.Warnf("ChannelMessageDelete(): %v", err)
@@ -142,7 +160,7 @@ func (b *Bot) reactCreated(s *discordgo.Session, r *discordgo.MessageReactionAdd
 }
 
 func (b *Bot) messageDeleted(s *discordgo.Session, m *discordgo.MessageDelete) {
-
+	repost.DeleteFamily(s, m.ID)
 }
 
 func (b *Bot) guildCreated(s *discordgo.Session, g *discordgo.GuildCreate) {
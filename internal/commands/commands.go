@@ -1,11 +1,9 @@
 package commands
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/VTGare/boe-tea-go/internal/database"
-	"github.com/VTGare/boe-tea-go/utils"
 	"github.com/VTGare/gumi"
 	"github.com/bwmarrin/discordgo"
 )
@@ -15,25 +13,8 @@ var (
 )
 
 func init() {
-	Router = gumi.NewGumi(gumi.WithErrorHandler(func(e error) *discordgo.MessageSend {
-		if e != nil {
-			embed := &discordgo.MessageEmbed{
-				Title: "Oops, something went wrong!",
-				Thumbnail: &discordgo.MessageEmbedThumbnail{
-					URL: utils.DefaultEmbedImage,
-				},
-				Description: fmt.Sprintf("***Error message:***\n%v\n\nPlease contact bot's author using bt!feedback command or directly at VTGare#3599 if you can't understand the error. ", e),
-				Color:       utils.EmbedColor,
-				Timestamp:   utils.EmbedTimestamp(),
-			}
-
-			return &discordgo.MessageSend{
-				Embed: embed,
-			}
-		}
-		return nil
-	}), gumi.WithPrefixResolver(func(g *gumi.Gumi, s *discordgo.Session, m *discordgo.MessageCreate) []string {
-		if guild, ok := database.GuildCache[m.GuildID]; ok {
+	Router = gumi.NewGumi(gumi.WithErrorHandler(errorMessage), gumi.WithPrefixResolver(func(g *gumi.Gumi, s *discordgo.Session, m *discordgo.MessageCreate) []string {
+		if guild := database.Settings(m.GuildID, m.ChannelID); guild != nil {
 			if guild.Prefix == "bt!" {
 				return []string{"bt!", "bt ", "bt.", "<@!" + s.State.User.ID + ">"}
 			}
@@ -41,6 +22,7 @@ func init() {
 		}
 		return []string{"bt!", "bt ", "bt.", "<@!" + s.State.User.ID + ">"}
 	}))
+	Router.HelpCommand = localizedHelp(Router.HelpCommand)
 
 	generalGroup := Router.Groups["general"]
 	generalGroup.AddCommand(&gumi.Command{
@@ -67,51 +49,23 @@ func init() {
 		Exec:        invite,
 	})
 
-	setHelp := gumi.NewHelpSettings()
-	setHelp.ExtendedHelp = []*discordgo.MessageEmbedField{
-		{
-			Name:  "Usage",
-			Value: "bt!set ``<setting>`` ``<new setting>``",
-		},
-		{
-			Name:  "prefix",
-			Value: "Bot's prefix. Up to ***5 characters***. If last character is a letter whitespace is assumed (takes one character).",
-		},
-		{
-			Name:  "largeset",
-			Value: "Album size considered as large and invokes a prompt when posted.",
-		},
-		{
-			Name:  "limit",
-			Value: "Hard limit for album size. Only first image from an album will be posted if album size exceeded limit.",
-		},
-		{
-			Name:  "pixiv | twitter",
-			Value: "Pixiv or Twitter reposting switch, valid parameters: ***[enabled, on, t, true], [disabled, off, f, false]***",
-		},
-		{
-			Name:  "repost",
-			Value: "Repost check setting, valid parameters: ***[enabled, disabled, strict]***. Strict mode disables a prompt and removes reposts on sight.",
-		},
-		{
-			Name:  "reversesearch",
-			Value: "Default reverse image search engine. Available options: ***[saucenao, wait]***",
-		},
-		{
-			Name:  "promptemoji",
-			Value: "Confirmation prompt emoji. Only unicode or local server emoji's are allowed.",
-		},
-	}
-
-	generalGroup.AddCommand(&gumi.Command{
+	setCmd := generalGroup.AddCommand(&gumi.Command{
 		Name:        "set",
 		Aliases:     []string{"config", "cfg", "settings"},
 		Description: "Show or change server's settings",
-		Help:        setHelp,
 		Exec:        set,
 		GuildOnly:   true,
 		Cooldown:    5 * time.Second,
 	})
+	setCmd.Help.ExtendedHelp = setHelp(nil)
+
+	localeCmd := generalGroup.AddCommand(&gumi.Command{
+		Name:        "locale",
+		Aliases:     []string{"language", "lang"},
+		Description: "Show or change your language",
+		Exec:        userLocale,
+	})
+	localeCmd.Help.AddField("Usage", "bt!locale ``[<en | ja | reset>]``. Your language overrides server's language, ***reset*** follows server's language again.", false)
 
 	generalGroup.AddCommand(&gumi.Command{
 		Name:        "support",
@@ -1,7 +1,13 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -73,34 +79,214 @@ func init() {
 		Help:        gumi.NewHelpSettings(),
 	})
 	copyc.Help.AddField("Usage", "bt!copy <source group name> <destination group name> <parent ID>", false)
+
+	server := cp.AddCommand(&gumi.Command{
+		Name:        "server",
+		Aliases:     []string{"guild", "sg"},
+		Description: "Manages server-wide cross-post groups that apply to every member's posts",
+		Exec:        serverGroups,
+		GuildOnly:   true,
+		Cooldown:    5 * time.Second,
+		Help:        gumi.NewHelpSettings(),
+	})
+	server.Help.AddField("Usage", "bt!server <list | create | delete | push | pop> <group name> [channel IDs or mentions]", false)
+	server.Help.AddField("Permissions", "Everything except ``list`` requires Administrator permission or a bot manager role, see ``bt!help set``. All channels must belong to this server.", false)
+	server.Help.AddField("Example", "``bt!server create art #art`` then ``bt!server push art #art-archive``", false)
+	server.Help.AddField("Filters", "``bt!server filter <group name> <channel> <rule> [values]``, see ``bt!help filter`` for rules", false)
+	server.Help.AddField("Group settings", "``bt!server set <group name> <setting> <value>``, see ``bt!help groupset`` for settings", false)
+	server.Help.AddField("Sources", "``bt!server source <group name> <add | remove> [channels]``, see ``bt!help source``", false)
+	server.Help.AddField("Pausing", "``bt!server toggle <group name>``, ``bt!server pause <group name> <duration>``, ``bt!server resume <group name>``", false)
+
+	filter := cp.AddCommand(&gumi.Command{
+		Name:        "filter",
+		Aliases:     []string{"filters"},
+		Description: "Sets which posts are cross-posted to a channel of a group",
+		Exec:        filterGroup,
+		Cooldown:    5 * time.Second,
+		Help:        gumi.NewHelpSettings(),
+	})
+	filter.Help.AddField("Usage", "bt!filter <group name> <channel> <rule> [values]", false)
+	filter.Help.AddField("tags", "Only posts with at least one of given Pixiv tags are cross-posted. No values clear the rule.", false)
+	filter.Help.AddField("exclude", "Posts with any of given Pixiv tags are not cross-posted. No values clear the rule.", false)
+	filter.Help.AddField("rating", "``sfw``, ``nsfw`` or ``any``. Tweets are considered SFW, attachments have a rating of the channel they were posted in.", false)
+	filter.Help.AddField("provider", "``pixiv``, ``twitter``, ``attachments`` or ``any``.", false)
+	filter.Help.AddField("likes", "Minimum amount of likes.", false)
+	filter.Help.AddField("clear", "Removes all rules.", false)
+	filter.Help.AddField("Example", "``bt!filter vtubers #hololive tags ホロライブ``", false)
+
+	gset := cp.AddCommand(&gumi.Command{
+		Name:        "groupset",
+		Aliases:     []string{"gset"},
+		Description: "Changes settings of a cross-post group",
+		Exec:        setGroup,
+		Cooldown:    5 * time.Second,
+		Help:        gumi.NewHelpSettings(),
+	})
+	gset.Help.AddField("Usage", "bt!groupset <group name> <setting> <value>", false)
+	gset.Help.AddField("attachments", "Boolean, enabled by default. Re-uploads image attachments of posts with their caption.", false)
+	gset.Help.AddField("mesh", "Boolean. Every channel of a group becomes both a source and a destination.", false)
+	gset.Help.AddField("webhook", "Boolean. Delivers cross-posts through webhooks under your name and avatar. Requires Manage Webhooks permission in child channels, otherwise regular messages are sent.", false)
+
+	toggle := cp.AddCommand(&gumi.Command{
+		Name:        "toggle",
+		Description: "Turns cross-posting of your posts or a single group on and off",
+		Exec:        toggleCrosspost,
+		Cooldown:    5 * time.Second,
+		Help:        gumi.NewHelpSettings(),
+	})
+	toggle.Help.AddField("Usage", "bt!toggle [group name]", false)
+	toggle.Help.AddField("group name", "Omit to toggle cross-posting of all your posts, including server groups.", false)
+
+	pause := cp.AddCommand(&gumi.Command{
+		Name:        "pause",
+		Description: "Temporarily stops cross-posting of your posts or a single group",
+		Exec:        pauseCrosspost,
+		Cooldown:    5 * time.Second,
+		Help:        gumi.NewHelpSettings(),
+	})
+	pause.Help.AddField("Usage", "bt!pause <duration> [group name]", false)
+	pause.Help.AddField("Duration", "A number with a unit: ``30m``, ``2h``, ``1d``.", false)
+	pause.Help.AddField("Example", "``bt!pause 2h`` or ``bt!pause 1d hololive``", false)
+
+	resume := cp.AddCommand(&gumi.Command{
+		Name:        "resume",
+		Description: "Turns cross-posting of your posts or a single group back on",
+		Exec:        resumeCrosspost,
+		Cooldown:    5 * time.Second,
+		Help:        gumi.NewHelpSettings(),
+	})
+	resume.Help.AddField("Usage", "bt!resume [group name]", false)
+
+	source := cp.AddCommand(&gumi.Command{
+		Name:        "source",
+		Aliases:     []string{"sources", "parents"},
+		Description: "Adds or removes additional source channels of a group",
+		Exec:        sourceGroup,
+		Cooldown:    5 * time.Second,
+		Help:        gumi.NewHelpSettings(),
+	})
+	source.Help.AddField("Usage", "bt!source <group name> <add | remove> [channel IDs or mentions]", false)
+	source.Help.AddField("Mirroring", "To mirror channels both ways, turn on mesh mode with ``bt!groupset <group name> mesh on``, then posts from any channel of the group are cross-posted to all others.", false)
+
+	export := cp.AddCommand(&gumi.Command{
+		Name:        "export",
+		Description: "Sends your cross-post groups as a JSON file",
+		Exec:        exportGroups,
+		Cooldown:    15 * time.Second,
+		Help:        gumi.NewHelpSettings(),
+	})
+	export.Help.AddField("Usage", "bt!export", false)
+
+	imp := cp.AddCommand(&gumi.Command{
+		Name:        "import",
+		Description: "Restores cross-post groups from an attached JSON file",
+		Exec:        importGroups,
+		Cooldown:    15 * time.Second,
+		Help:        gumi.NewHelpSettings(),
+	})
+	imp.Help.AddField("Usage", "bt!import [merge | replace]", false)
+	imp.Help.AddField("merge", "Default. Imported groups overwrite your groups with the same name, other groups are kept.", false)
+	imp.Help.AddField("replace", "Removes all your groups before importing.", false)
+}
+
+type groupSettingFunc func(*database.Group, string) error
+
+var groupSettingMap = map[string]groupSettingFunc{
+	"webhook": func(g *database.Group, str string) error {
+		b, err := utils.ParseBool(str)
+		if err != nil {
+			return err
+		}
+
+		g.Webhook = b
+		return nil
+	},
+	"attachments": func(g *database.Group, str string) error {
+		b, err := utils.ParseBool(str)
+		if err != nil {
+			return err
+		}
+
+		g.NoAttachments = !b
+		return nil
+	},
+	"mesh": func(g *database.Group, str string) error {
+		b, err := utils.ParseBool(str)
+		if err != nil {
+			return err
+		}
+
+		g.Mesh = b
+		return nil
+	},
+}
+
+//groupDescription formats parent, children and filters of a cross-post group for an embed field.
+func groupDescription(m *discordgo.MessageCreate, g *database.Group) string {
+	children := "-"
+	if len(g.Children) > 0 {
+		children = strings.Join(utils.Map(g.Children, func(str string) string {
+			return fmt.Sprintf("<#%v>", str)
+		}), " ")
+	}
+
+	desc := translate(m, "group.description", g.Parent, children)
+	switch {
+	case g.Disabled:
+		desc += "\n" + translate(m, "group.status_disabled")
+	case !g.Active():
+		desc += "\n" + translate(m, "group.status_paused", g.PausedUntil.UTC().Format(pauseLayout))
+	}
+	if len(g.Parents) > 0 {
+		desc += "\n" + translate(m, "group.sources", strings.Join(utils.Map(g.Parents, func(str string) string {
+			return fmt.Sprintf("<#%v>", str)
+		}), " "))
+	}
+	if g.Mesh {
+		desc += "\n" + translate(m, "group.mesh")
+	}
+	if g.Webhook {
+		desc += "\n" + translate(m, "group.webhook")
+	}
+	if g.NoAttachments {
+		desc += "\n" + translate(m, "group.no_attachments")
+	}
+	locales := database.Locales(m.GuildID, m.Author.ID)
+	for _, c := range g.Children {
+		if f := g.Filter(c); !f.IsEmpty() {
+			desc += fmt.Sprintf("\n<#%v> %v", c, f.Describe(locales))
+		}
+	}
+
+	return desc
 }
 
 func groups(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
 	user := database.DB.FindUser(m.Author.ID)
 	if user == nil {
-		return fmt.Errorf("user settings not found, create create a group first with the following command: ``bt!create <group name> <parent ID>``")
+		return errors.New(translate(m, "group.no_user"))
 	}
 
 	embed := &discordgo.MessageEmbed{
-		Title:     fmt.Sprintf("%v's cross-post groups", m.Author.Username),
+		Title:     translate(m, "group.title", m.Author.Username),
 		Color:     utils.EmbedColor,
 		Timestamp: utils.EmbedTimestamp(),
 		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: m.Author.AvatarURL("")},
 	}
 
+	switch {
+	case !user.Crosspost:
+		embed.Description = translate(m, "crosspost.off")
+	case !user.CrosspostEnabled():
+		embed.Description = translate(m, "crosspost.paused", user.PausedUntil.UTC().Format(pauseLayout))
+	}
+
 	for _, g := range user.ChannelGroups {
-		if len(g.Children) > 0 {
-			children := utils.Map(g.Children, func(str string) string {
-				return fmt.Sprintf("<#%v>", str)
-			})
-			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: g.Name, Value: fmt.Sprintf("**Parent:** [<#%v>]\n**Children:** %v", g.Parent, children)})
-		} else {
-			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: g.Name, Value: fmt.Sprintf("**Parent:** [<#%v>]\n**Children:** -", g.Parent)})
-		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: g.Name, Value: groupDescription(m, g)})
 	}
 
 	if len(embed.Fields) == 0 {
-		embed.Description = ":gun:🤠 *This town ain't big enough for the both of us!*\n"
+		embed.Description = translate(m, "group.empty")
 		embed.Image = &discordgo.MessageEmbedImage{URL: "https://thumbs.gfycat.com/InconsequentialPerfumedGadwall-size_restricted.gif"}
 	}
 
@@ -110,7 +296,7 @@ func groups(s *discordgo.Session, m *discordgo.MessageCreate, args []string) err
 
 func createGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
 	if len(args) < 2 {
-		return fmt.Errorf("``bt!create`` requires two arguments. Example: ``bt!create touhou #lewdtouhouart``")
+		return errors.New(translate(m, "group.create_usage"))
 	}
 
 	user := database.DB.FindUser(m.Author.ID)
@@ -118,7 +304,7 @@ func createGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 		user = database.NewUserSettings(m.Author.ID)
 		err := database.DB.InsertOneUser(user)
 		if err != nil {
-			return fmt.Errorf("Fatal database error: %v", err)
+			return errors.New(translate(m, "error.database", err))
 		}
 	}
 
@@ -131,52 +317,55 @@ func createGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 		ch = strings.Trim(ch, "<#>")
 	}
 	if _, err := s.State.Channel(ch); err != nil {
-		return fmt.Errorf("unable to find channel ``%v``. Make sure Boe Tea is present on the server and able to read the channel", ch)
+		return errors.New(translate(m, "group.unknown_channel", ch))
+	}
+	if err := utils.CanCrosspost(s, m.Author.ID, ch); err != nil {
+		return fmt.Errorf("<#%v>: %v", ch, err)
 	}
 
 	err := database.DB.CreateGroup(m.Author.ID, groupName, ch)
 	if err != nil {
-		return fmt.Errorf("Fatal database error: %v", err)
+		return errors.New(translate(m, "error.database", err))
 	}
 
 	s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-		Title:     "✅ Sucessfully created a cross-post group!",
+		Title:     translate(m, "group.created"),
 		Color:     utils.EmbedColor,
 		Timestamp: utils.EmbedTimestamp(),
 		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-		Fields:    []*discordgo.MessageEmbedField{{Name: "Name", Value: groupName}, {Name: "Parent channel", Value: fmt.Sprintf("<#%v>", ch)}},
+		Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.name"), Value: groupName}, {Name: translate(m, "group.parent_channel"), Value: fmt.Sprintf("<#%v>", ch)}},
 	})
 	return nil
 }
 
 func deleteGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("``bt!delete`` requires at least one argument.\n**Usage:** ``bt!delete ntr``")
+		return errors.New(translate(m, "group.delete_usage"))
 	}
 
 	user := database.DB.FindUser(m.Author.ID)
 	if user == nil {
 		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "❎ Failed to delete a cross-post group!",
+			Title:     translate(m, "group.delete_failed"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields:    []*discordgo.MessageEmbedField{{Name: "Reason", Value: "You have no cross-post groups yet."}},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "command.reason"), Value: translate(m, "group.no_groups")}},
 		})
 		return nil
 	}
 
 	err := database.DB.DeleteGroup(m.Author.ID, args[0])
 	if err != nil {
-		return fmt.Errorf("Fatal database error: %v", err)
+		return errors.New(translate(m, "error.database", err))
 	}
 
 	s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-		Title:     "✅ Sucessfully deleted a cross-post group!",
+		Title:     translate(m, "group.deleted"),
 		Color:     utils.EmbedColor,
 		Timestamp: utils.EmbedTimestamp(),
 		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-		Fields:    []*discordgo.MessageEmbedField{{Name: "Name", Value: args[0]}},
+		Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.name"), Value: args[0]}},
 	})
 
 	return nil
@@ -184,17 +373,17 @@ func deleteGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 
 func removeFromGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
 	if len(args) < 2 {
-		return fmt.Errorf("``bt!remove`` requires at least two arguments.\n**Usage:** ``bt!remove nudes #nsfw``")
+		return errors.New(translate(m, "group.remove_usage"))
 	}
 
 	user := database.DB.FindUser(m.Author.ID)
 	if user == nil {
 		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "❎ Failed to remove from a cross-post group!",
+			Title:     translate(m, "group.remove_failed"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields:    []*discordgo.MessageEmbedField{{Name: "Reason", Value: "You have no cross-post groups yet."}},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "command.reason"), Value: translate(m, "group.no_groups")}},
 		})
 		return nil
 	}
@@ -205,26 +394,26 @@ func removeFromGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []st
 
 	found, err := database.DB.RemoveFromGroup(m.Author.ID, args[0], ids...)
 	if err != nil {
-		return fmt.Errorf("Fatal database error: %v", err)
+		return errors.New(translate(m, "error.database", err))
 	}
 
 	if len(found) > 0 {
 		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "✅ Sucessfully removed channels from a cross-post group!",
+			Title:     translate(m, "group.removed"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields: []*discordgo.MessageEmbedField{{Name: "Group name", Value: args[0]}, {Name: "Channels", Value: strings.Join(utils.Map(found, func(s string) string {
+			Fields: []*discordgo.MessageEmbedField{{Name: translate(m, "group.group_name"), Value: args[0]}, {Name: translate(m, "group.channels"), Value: strings.Join(utils.Map(found, func(s string) string {
 				return fmt.Sprintf("<#%v>", s)
 			}), " ")}},
 		})
 	} else {
 		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "❎ Failed to remove channels from a cross-post group!",
+			Title:     translate(m, "group.remove_channels_failed"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields:    []*discordgo.MessageEmbedField{{Name: "Group name", Value: args[0]}, {Name: "Reason", Value: "No valid channels were found"}},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.group_name"), Value: args[0]}, {Name: translate(m, "command.reason"), Value: translate(m, "group.no_channels")}},
 		})
 	}
 
@@ -233,17 +422,17 @@ func removeFromGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []st
 
 func addToGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
 	if len(args) < 2 {
-		return fmt.Errorf("``bt!push`` requires at least two arguments.\n**Usage:** ``bt!push hololive #marine-booty``")
+		return errors.New(translate(m, "group.add_usage"))
 	}
 
 	user := database.DB.FindUser(m.Author.ID)
 	if user == nil {
 		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "❎ Failed to add to a cross-post group!",
+			Title:     translate(m, "group.add_failed"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields:    []*discordgo.MessageEmbedField{{Name: "Reason", Value: "You have no cross-post groups yet."}},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "command.reason"), Value: translate(m, "group.no_groups")}},
 		})
 		return nil
 	}
@@ -259,37 +448,43 @@ func addToGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string)
 	group, _ := user.FindGroup(groupName)
 	if group == nil {
 		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "❎ Failed to add to a cross-post group!",
+			Title:     translate(m, "group.add_failed"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields:    []*discordgo.MessageEmbedField{{Name: "Reason", Value: fmt.Sprintf("Cross-post group **%v** has not been found.", groupName)}},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "command.reason"), Value: translate(m, "group.not_found", groupName)}},
 		})
 		return nil
 	}
 
 	existsMap := make(map[string]bool, 0)
-	existsMap[group.Parent] = true
-	for _, id := range group.Children {
+	for _, id := range group.Members() {
 		existsMap[id] = true
 	}
 
+	skipped := make([]string, 0)
 	for ch := range channelsMap {
 		if strings.HasPrefix(ch, "<#") {
 			ch = strings.Trim(ch, "<#>")
 		}
 
 		if _, err := s.State.Channel(ch); err != nil {
-			return fmt.Errorf("unable to find channel ``%v``. Make sure Boe Tea is present on the server and able to read the channel", ch)
+			skipped = append(skipped, translate(m, "group.skipped_channel", ch))
+			continue
+		}
+
+		if err := utils.CanCrosspost(s, m.Author.ID, ch); err != nil {
+			skipped = append(skipped, fmt.Sprintf("<#%v>: %v", ch, err))
+			continue
 		}
 
 		if _, ok := existsMap[ch]; ok {
 			s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-				Title:     "❎ Failed to add to a cross-post group!",
+				Title:     translate(m, "group.add_failed"),
 				Color:     utils.EmbedColor,
 				Timestamp: utils.EmbedTimestamp(),
 				Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-				Fields:    []*discordgo.MessageEmbedField{{Name: "Reason", Value: fmt.Sprintf("Channel <#%v> is already part of group %v", ch, groupName)}},
+				Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "command.reason"), Value: translate(m, "group.already_member", ch, groupName)}},
 			})
 			return nil
 		}
@@ -297,62 +492,71 @@ func addToGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string)
 		channels = append(channels, ch)
 	}
 
-	added, err := database.DB.AddToGroup(m.Author.ID, groupName, channels...)
-	if err != nil {
-		return fmt.Errorf("Fatal database error: %v", err)
+	added := make([]string, 0)
+	if len(channels) > 0 {
+		var err error
+		added, err = database.DB.AddToGroup(m.Author.ID, groupName, channels...)
+		if err != nil {
+			return errors.New(translate(m, "error.database", err))
+		}
 	}
 
+	var embed *discordgo.MessageEmbed
 	if len(added) > 0 {
-		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "✅ Sucessfully added channels to a cross-post group!",
+		embed = &discordgo.MessageEmbed{
+			Title:     translate(m, "group.added"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields: []*discordgo.MessageEmbedField{{Name: "Name", Value: args[0]}, {Name: "Channels", Value: strings.Join(utils.Map(added, func(s string) string {
+			Fields: []*discordgo.MessageEmbedField{{Name: translate(m, "group.name"), Value: args[0]}, {Name: translate(m, "group.channels"), Value: strings.Join(utils.Map(added, func(s string) string {
 				return fmt.Sprintf("<#%v>", s)
 			}), " ")}},
-		})
+		}
 	} else {
-		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "❎ Failed to add channels to a cross-post group!",
+		embed = &discordgo.MessageEmbed{
+			Title:     translate(m, "group.add_channels_failed"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields:    []*discordgo.MessageEmbedField{{Name: "Group name", Value: args[0]}, {Name: "Reason", Value: "No valid channels were found"}},
-		})
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.group_name"), Value: args[0]}, {Name: translate(m, "command.reason"), Value: translate(m, "group.no_channels")}},
+		}
+	}
+
+	if len(skipped) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: translate(m, "group.skipped_channels"), Value: strings.Join(skipped, "\n")})
 	}
 
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
 	return nil
 }
 
 func copyGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
 	if len(args) < 3 {
-		return fmt.Errorf("``bt!copy`` requires at least three arguments.\n**Usage:** ``bt!copy <source> <destination> <new parent channel>``")
+		return errors.New(translate(m, "group.copy_usage"))
 	}
 
 	user := database.DB.FindUser(m.Author.ID)
 	if user == nil {
 		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "❎ Failed to copy a cross-post group!",
+			Title:     translate(m, "group.copy_failed"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields:    []*discordgo.MessageEmbedField{{Name: "Reason", Value: "You have no cross-post groups yet."}},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "command.reason"), Value: translate(m, "group.no_groups")}},
 		})
 		return nil
 	}
 
 	var (
-		group    *database.Group
-		src      = args[0]
-		dest     = args[1]
-		exists   bool
-		parent   = strings.Trim(args[2], "<#>")
-		isParent bool
+		group  *database.Group
+		src    = args[0]
+		dest   = args[1]
+		exists bool
+		parent = strings.Trim(args[2], "<#>")
 	)
 
 	if _, err := s.State.Channel(parent); err != nil {
-		return fmt.Errorf("unable to find channel ``%v``. Make sure Boe Tea is present on the server and able to read the channel", parent)
+		return errors.New(translate(m, "group.unknown_channel", parent))
 	}
 
 	for _, g := range user.ChannelGroups {
@@ -363,41 +567,44 @@ func copyGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string)
 		if g.Name == dest {
 			exists = true
 		}
-
-		if g.Parent == parent {
-			isParent = true
-		}
 	}
 
 	if group == nil {
 		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "❎ Failed to copy a cross-post group!",
+			Title:     translate(m, "group.copy_failed"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields:    []*discordgo.MessageEmbedField{{Name: "Reason", Value: "Couldn't find a source group ``" + src + "``"}},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "command.reason"), Value: translate(m, "group.no_source", src)}},
 		})
 		return nil
 	}
 
-	if isParent {
+	if exists {
 		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "❎ Failed to copy a cross-post group!",
+			Title:     translate(m, "group.copy_failed"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields:    []*discordgo.MessageEmbedField{{Name: "Reason", Value: fmt.Sprintf("Channel <#%v> is already a parent channel", parent)}},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "command.reason"), Value: translate(m, "group.name_taken", dest)}},
 		})
 		return nil
 	}
 
-	if exists {
+	denied := make([]string, 0)
+	for _, c := range append([]string{parent}, group.Children...) {
+		if err := utils.CanCrosspost(s, m.Author.ID, c); err != nil {
+			denied = append(denied, fmt.Sprintf("<#%v>: %v", c, err))
+		}
+	}
+
+	if len(denied) > 0 {
 		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "❎ Failed to copy a cross-post group!",
+			Title:     translate(m, "group.copy_failed"),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-			Fields:    []*discordgo.MessageEmbedField{{Name: "Reason", Value: fmt.Sprintf("Group name %v is already taken", dest)}},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "command.reason"), Value: strings.Join(denied, "\n")}},
 		})
 		return nil
 	}
@@ -417,18 +624,799 @@ func copyGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string)
 
 	err := database.DB.PushGroup(m.Author.ID, new)
 	if err != nil {
-		return fmt.Errorf("Fatal database error: %v", err)
+		return errors.New(translate(m, "error.database", err))
 	}
 
 	s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-		Title:     "✅ Sucessfully copied a cross-post group!",
+		Title:     translate(m, "group.copied"),
 		Color:     utils.EmbedColor,
 		Timestamp: utils.EmbedTimestamp(),
 		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
-		Fields: []*discordgo.MessageEmbedField{{Name: "Name", Value: new.Name}, {Name: "Parent", Value: fmt.Sprintf("<#%v>", new.Parent)}, {Name: "Channels", Value: strings.Join(utils.Map(new.Children, func(s string) string {
+		Fields: []*discordgo.MessageEmbedField{{Name: translate(m, "group.name"), Value: new.Name}, {Name: translate(m, "group.parent"), Value: fmt.Sprintf("<#%v>", new.Parent)}, {Name: translate(m, "group.channels"), Value: strings.Join(utils.Map(new.Children, func(s string) string {
 			return fmt.Sprintf("<#%v>", s)
 		}), " ")}},
 	})
 
 	return nil
 }
+
+func serverGroups(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) == 0 || args[0] == "list" || args[0] == "ls" {
+		return listServerGroups(s, m)
+	}
+
+	isManager, err := isBotManager(s, m)
+	if err != nil {
+		return err
+	}
+	if !isManager {
+		return utils.ErrNoPermission
+	}
+
+	switch args[0] {
+	case "filter":
+		return filterServerGroup(s, m, args[1:])
+	case "set":
+		return setServerGroup(s, m, args[1:])
+	case "source", "sources":
+		if len(args) < 4 {
+			return errors.New(translate(m, "server.source_usage"))
+		}
+
+		channels, err := serverChannels(s, m, args[3:])
+		if err != nil {
+			return err
+		}
+
+		return editSources(s, m, func(name string, edit func(*database.Group) error) error {
+			return database.DB.EditGuildGroup(m.GuildID, m.Author.ID, name, edit)
+		}, args[1], args[2], channels)
+	case "toggle", "pause", "resume":
+		if len(args) < 2 {
+			return errors.New(translate(m, "server.group_required", args[0]))
+		}
+
+		editor := func(name string, edit func(*database.Group) error) error {
+			return database.DB.EditGuildGroup(m.GuildID, m.Author.ID, name, edit)
+		}
+
+		switch args[0] {
+		case "toggle":
+			return toggleGroup(s, m, editor, args[1])
+		case "pause":
+			if len(args) < 3 {
+				return errors.New(translate(m, "server.duration_required"))
+			}
+
+			dur, err := parsePauseDuration(m, args[2])
+			if err != nil {
+				return err
+			}
+			return pauseGroup(s, m, editor, args[1], dur)
+		default:
+			return resumeGroup(s, m, editor, args[1])
+		}
+	}
+
+	if len(args) < 2 {
+		return errors.New(translate(m, "server.usage", args[0]))
+	}
+
+	var (
+		action    = args[0]
+		groupName = args[1]
+	)
+
+	channels, err := serverChannels(s, m, args[2:])
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "create", "new":
+		if len(channels) != 1 {
+			return errors.New(translate(m, "server.one_parent"))
+		}
+
+		if err := database.DB.CreateGuildGroup(m.GuildID, m.Author.ID, groupName, channels[0]); err != nil {
+			return errors.New(translate(m, "error.database", err))
+		}
+
+		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+			Title:     translate(m, "server.created"),
+			Color:     utils.EmbedColor,
+			Timestamp: utils.EmbedTimestamp(),
+			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.name"), Value: groupName}, {Name: translate(m, "group.parent_channel"), Value: fmt.Sprintf("<#%v>", channels[0])}},
+		})
+	case "delete", "remove":
+		if err := database.DB.DeleteGuildGroup(m.GuildID, m.Author.ID, groupName); err != nil {
+			return errors.New(translate(m, "error.database", err))
+		}
+
+		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+			Title:     translate(m, "server.deleted"),
+			Color:     utils.EmbedColor,
+			Timestamp: utils.EmbedTimestamp(),
+			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.name"), Value: groupName}},
+		})
+	case "push", "add", "pop":
+		if len(channels) == 0 {
+			return errors.New(translate(m, "server.channels_required", action))
+		}
+
+		var (
+			changed []string
+			err     error
+			title   = translate(m, "server.added")
+		)
+
+		if action == "pop" {
+			changed, err = database.DB.RemoveFromGuildGroup(m.GuildID, m.Author.ID, groupName, channels...)
+			title = translate(m, "server.removed")
+		} else {
+			changed, err = database.DB.AddToGuildGroup(m.GuildID, m.Author.ID, groupName, channels...)
+		}
+
+		if err != nil {
+			return errors.New(translate(m, "error.database", err))
+		}
+
+		if len(changed) == 0 {
+			s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+				Title:     translate(m, "server.edit_failed"),
+				Color:     utils.EmbedColor,
+				Timestamp: utils.EmbedTimestamp(),
+				Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+				Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.group_name"), Value: groupName}, {Name: translate(m, "command.reason"), Value: translate(m, "group.no_channels")}},
+			})
+			return nil
+		}
+
+		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+			Title:     title,
+			Color:     utils.EmbedColor,
+			Timestamp: utils.EmbedTimestamp(),
+			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+			Fields: []*discordgo.MessageEmbedField{{Name: translate(m, "group.name"), Value: groupName}, {Name: translate(m, "group.channels"), Value: strings.Join(utils.Map(changed, func(s string) string {
+				return fmt.Sprintf("<#%v>", s)
+			}), " ")}},
+		})
+	default:
+		return errors.New(translate(m, "server.unknown_action", action))
+	}
+
+	return nil
+}
+
+func listServerGroups(s *discordgo.Session, m *discordgo.MessageCreate) error {
+	guild := database.GuildCache[m.GuildID]
+	embed := &discordgo.MessageEmbed{
+		Title:     translate(m, "server.title"),
+		Color:     utils.EmbedColor,
+		Timestamp: utils.EmbedTimestamp(),
+		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+	}
+
+	for _, g := range guild.ChannelGroups {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: g.Name, Value: groupDescription(m, g)})
+	}
+
+	if len(embed.Fields) == 0 {
+		embed.Description = translate(m, "server.empty")
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	return nil
+}
+
+func filterGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) < 3 {
+		return errors.New(translate(m, "filter.usage"))
+	}
+
+	user := database.DB.FindUser(m.Author.ID)
+	if user == nil {
+		return errors.New(translate(m, "group.no_groups"))
+	}
+
+	var (
+		groupName = args[0]
+		channelID = strings.Trim(args[1], "<#>")
+	)
+
+	group, _ := user.FindGroup(groupName)
+	if group == nil {
+		return errors.New(translate(m, "group.not_found", groupName))
+	}
+
+	filter, err := applyFilterRule(m, group, channelID, args[2], args[3:])
+	if err != nil {
+		return err
+	}
+
+	if err := database.DB.SetGroupFilter(m.Author.ID, groupName, channelID, filter); err != nil {
+		return errors.New(translate(m, "error.database", err))
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, filterEmbed(m, groupName, channelID, filter))
+	return nil
+}
+
+func filterServerGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) < 3 {
+		return errors.New(translate(m, "server.filter_usage"))
+	}
+
+	var (
+		guild     = database.GuildCache[m.GuildID]
+		groupName = args[0]
+		channelID = strings.Trim(args[1], "<#>")
+	)
+
+	group, _ := guild.FindGroup(groupName)
+	if group == nil {
+		return errors.New(translate(m, "server.not_found", groupName))
+	}
+
+	filter, err := applyFilterRule(m, group, channelID, args[2], args[3:])
+	if err != nil {
+		return err
+	}
+
+	if err := database.DB.SetGuildGroupFilter(m.GuildID, m.Author.ID, groupName, channelID, filter); err != nil {
+		return errors.New(translate(m, "error.database", err))
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, filterEmbed(m, groupName, channelID, filter))
+	return nil
+}
+
+//applyFilterRule returns a copy of child channel's filter with a rule changed.
+func applyFilterRule(m *discordgo.MessageCreate, group *database.Group, channelID, rule string, values []string) (*database.Filter, error) {
+	isDestination := false
+	for _, c := range group.Members() {
+		if c == channelID && (group.Mesh || !group.IsSource(c)) {
+			isDestination = true
+			break
+		}
+	}
+
+	if !isDestination {
+		return nil, errors.New(translate(m, "filter.not_destination", channelID, group.Name))
+	}
+
+	filter := &database.Filter{}
+	if f := group.Filter(channelID); f != nil {
+		*filter = *f
+	}
+
+	switch rule {
+	case "tags", "include":
+		filter.IncludeTags = values
+	case "exclude", "notags":
+		filter.ExcludeTags = values
+	case "rating":
+		if len(values) == 0 {
+			return nil, errors.New(translate(m, "filter.rating_required"))
+		}
+
+		switch values[0] {
+		case "sfw", "nsfw":
+			filter.Rating = values[0]
+		case "any":
+			filter.Rating = ""
+		default:
+			return nil, errors.New(translate(m, "filter.bad_rating", values[0]))
+		}
+	case "provider", "providers":
+		filter.Providers = nil
+		for _, p := range values {
+			switch p {
+			case "pixiv", "twitter", "attachments":
+				filter.Providers = append(filter.Providers, p)
+			case "any":
+				filter.Providers = nil
+			default:
+				return nil, errors.New(translate(m, "filter.bad_provider", p))
+			}
+		}
+	case "likes":
+		if len(values) == 0 {
+			return nil, utils.ErrNotEnoughArguments
+		}
+
+		likes, err := strconv.Atoi(values[0])
+		if err != nil || likes < 0 {
+			return nil, utils.ErrParsingArgument
+		}
+		filter.MinLikes = likes
+	case "clear", "reset":
+		filter = &database.Filter{}
+	default:
+		return nil, errors.New(translate(m, "filter.bad_rule", rule))
+	}
+
+	return filter, nil
+}
+
+func filterEmbed(m *discordgo.MessageCreate, groupName, channelID string, filter *database.Filter) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:     translate(m, "filter.changed"),
+		Color:     utils.EmbedColor,
+		Timestamp: utils.EmbedTimestamp(),
+		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+		Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.group_name"), Value: groupName}, {Name: translate(m, "settings.channel"), Value: fmt.Sprintf("<#%v>", channelID)}, {Name: translate(m, "filter.filter"), Value: filter.Describe(database.Locales(m.GuildID, m.Author.ID))}},
+	}
+}
+
+func setGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) < 3 {
+		return errors.New(translate(m, "group.set_usage"))
+	}
+
+	edit, ok := groupSettingMap[args[1]]
+	if !ok {
+		return errors.New(translate(m, "group.unknown_setting", args[1]))
+	}
+
+	err := database.DB.EditGroup(m.Author.ID, args[0], func(g *database.Group) error {
+		return edit(g, args[2])
+	})
+	if err != nil {
+		return err
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, groupSettingEmbed(m, args[0], args[1], args[2]))
+	return nil
+}
+
+func setServerGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) < 3 {
+		return errors.New(translate(m, "server.set_usage"))
+	}
+
+	edit, ok := groupSettingMap[args[1]]
+	if !ok {
+		return errors.New(translate(m, "group.unknown_setting", args[1]))
+	}
+
+	err := database.DB.EditGuildGroup(m.GuildID, m.Author.ID, args[0], func(g *database.Group) error {
+		return edit(g, args[2])
+	})
+	if err != nil {
+		return err
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, groupSettingEmbed(m, args[0], args[1], args[2]))
+	return nil
+}
+
+func groupSettingEmbed(m *discordgo.MessageCreate, groupName, setting, value string) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:     translate(m, "group.setting_changed"),
+		Color:     utils.EmbedColor,
+		Timestamp: utils.EmbedTimestamp(),
+		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+		Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.group_name"), Value: groupName}, {Name: translate(m, "settings.setting"), Value: setting, Inline: true}, {Name: translate(m, "settings.new_value"), Value: value, Inline: true}},
+	}
+}
+
+//maxImportSize is a maximum size of an imported groups file.
+const maxImportSize = 1024 * 1024
+
+//importClient downloads imported groups files.
+var importClient = &http.Client{Timeout: 30 * time.Second}
+
+func exportGroups(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	user := database.DB.FindUser(m.Author.ID)
+	if user == nil || len(user.ChannelGroups) == 0 {
+		return errors.New(translate(m, "group.nothing_to_export"))
+	}
+
+	data, err := json.MarshalIndent(user.ChannelGroups, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = s.ChannelMessageSendComplex(m.ChannelID, &discordgo.MessageSend{
+		Content: translate(m, "group.exported", len(user.ChannelGroups)),
+		Files: []*discordgo.File{{
+			Name:        fmt.Sprintf("crosspost-%v.json", m.Author.ID),
+			ContentType: "application/json",
+			Reader:      bytes.NewReader(data),
+		}},
+	})
+	return err
+}
+
+func importGroups(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	mode := "merge"
+	if len(args) > 0 {
+		mode = args[0]
+	}
+
+	if mode != "merge" && mode != "replace" {
+		return errors.New(translate(m, "group.bad_import_mode", mode))
+	}
+
+	if len(m.Attachments) == 0 {
+		return errors.New(translate(m, "group.import_required"))
+	}
+
+	attachment := m.Attachments[0]
+	if attachment.Size > maxImportSize {
+		return errors.New(translate(m, "group.import_too_large", maxImportSize/1024))
+	}
+
+	resp, err := importClient.Get(attachment.URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(translate(m, "group.import_download", resp.Status))
+	}
+
+	imported := make([]*database.Group, 0)
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxImportSize)).Decode(&imported); err != nil {
+		return errors.New(translate(m, "group.import_read", err))
+	}
+
+	var (
+		groups      = make([]*database.Group, 0)
+		unreachable = make([]string, 0)
+		skipped     = make([]string, 0)
+		names       = make(map[string]bool)
+	)
+
+	if user := database.DB.FindUser(m.Author.ID); user != nil && mode == "merge" {
+		groups = append(groups, user.ChannelGroups...)
+	}
+
+	reachable := func(id string) bool {
+		if _, err := s.State.Channel(id); err != nil {
+			unreachable = append(unreachable, translate(m, "group.unreachable_channel", id))
+			return false
+		}
+
+		if err := utils.CanCrosspost(s, m.Author.ID, id); err != nil {
+			unreachable = append(unreachable, fmt.Sprintf("<#%v>: %v", id, err))
+			return false
+		}
+
+		return true
+	}
+
+	for _, g := range imported {
+		if g == nil || g.Name == "" || g.Parent == "" {
+			continue
+		}
+
+		if names[g.Name] {
+			skipped = append(skipped, translate(m, "group.duplicate_name", g.Name))
+			continue
+		}
+
+		if !reachable(g.Parent) {
+			skipped = append(skipped, translate(m, "group.unreachable_parent", g.Name))
+			continue
+		}
+
+		parents := make([]string, 0, len(g.Parents))
+		for _, p := range g.Parents {
+			if p != g.Parent && reachable(p) {
+				parents = append(parents, p)
+			}
+		}
+		g.Parents = parents
+
+		children := make([]string, 0, len(g.Children))
+		for _, c := range g.Children {
+			if !g.IsSource(c) && reachable(c) {
+				children = append(children, c)
+			} else {
+				delete(g.Filters, c)
+			}
+		}
+		g.Children = children
+
+		//imported groups overwrite existing ones with the same name
+		for ind, existing := range groups {
+			if existing.Name == g.Name {
+				groups = append(groups[:ind], groups[ind+1:]...)
+				break
+			}
+		}
+
+		names[g.Name] = true
+		groups = append(groups, g)
+	}
+
+	if err := database.DB.ReplaceGroups(m.Author.ID, groups); err != nil {
+		return errors.New(translate(m, "error.database", err))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:     translate(m, "group.imported"),
+		Color:     utils.EmbedColor,
+		Timestamp: utils.EmbedTimestamp(),
+		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+		Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.mode"), Value: mode, Inline: true}, {Name: translate(m, "group.imported_count"), Value: strconv.Itoa(len(names)), Inline: true}, {Name: translate(m, "group.groups"), Value: strconv.Itoa(len(groups)), Inline: true}},
+	}
+
+	if len(unreachable) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: translate(m, "group.unreachable"), Value: truncateField(strings.Join(unreachable, "\n"))})
+	}
+
+	if len(skipped) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: translate(m, "group.skipped_groups"), Value: truncateField(strings.Join(skipped, "\n"))})
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	return nil
+}
+
+//truncateField shortens a string to fit into an embed field value.
+func truncateField(str string) string {
+	runes := []rune(str)
+	if len(runes) <= 1024 {
+		return str
+	}
+
+	return string(runes[:1020]) + "\n..."
+}
+
+const pauseLayout = "Jan 2 15:04 MST"
+
+//groupEditor applies changes to a personal or a server cross-post group.
+type groupEditor func(groupName string, edit func(*database.Group) error) error
+
+func userGroupEditor(userID string) groupEditor {
+	return func(name string, edit func(*database.Group) error) error {
+		return database.DB.EditGroup(userID, name, edit)
+	}
+}
+
+//parsePauseDuration parses a Go duration with an additional day unit, e.g. 1d.
+func parsePauseDuration(m *discordgo.MessageCreate, str string) (time.Duration, error) {
+	var (
+		dur time.Duration
+		err error
+	)
+
+	if strings.HasSuffix(str, "d") {
+		var days int
+		days, err = strconv.Atoi(strings.TrimSuffix(str, "d"))
+		dur = time.Duration(days) * 24 * time.Hour
+	} else {
+		dur, err = time.ParseDuration(str)
+	}
+
+	if err != nil || dur <= 0 {
+		return 0, errors.New(translate(m, "group.bad_duration", str))
+	}
+
+	return dur, nil
+}
+
+func crosspostStatusEmbed(m *discordgo.MessageCreate, title, name, status string) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:     title,
+		Color:     utils.EmbedColor,
+		Timestamp: utils.EmbedTimestamp(),
+		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+		Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.group_name"), Value: name, Inline: true}, {Name: translate(m, "group.status"), Value: status, Inline: true}},
+	}
+}
+
+func toggleCrosspost(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) != 0 {
+		return toggleGroup(s, m, userGroupEditor(m.Author.ID), args[0])
+	}
+
+	enabled := true
+	if user := database.DB.FindUser(m.Author.ID); user != nil {
+		enabled = !user.CrosspostEnabled()
+	}
+
+	if err := database.DB.SetCrosspost(m.Author.ID, enabled, time.Time{}); err != nil {
+		return errors.New(translate(m, "error.database", err))
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, crosspostStatusEmbed(m, translate(m, "group.toggled_all"), translate(m, "group.all"), formatBool(m, enabled)))
+	return nil
+}
+
+func pauseCrosspost(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) == 0 {
+		return errors.New(translate(m, "group.pause_usage"))
+	}
+
+	dur, err := parsePauseDuration(m, args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 1 {
+		return pauseGroup(s, m, userGroupEditor(m.Author.ID), args[1], dur)
+	}
+
+	until := time.Now().Add(dur)
+	if err := database.DB.SetCrosspost(m.Author.ID, true, until); err != nil {
+		return errors.New(translate(m, "error.database", err))
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, crosspostStatusEmbed(m, translate(m, "group.paused_all"), translate(m, "group.all"), translate(m, "group.paused_until", until.UTC().Format(pauseLayout))))
+	return nil
+}
+
+func resumeCrosspost(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) != 0 {
+		return resumeGroup(s, m, userGroupEditor(m.Author.ID), args[0])
+	}
+
+	if err := database.DB.SetCrosspost(m.Author.ID, true, time.Time{}); err != nil {
+		return errors.New(translate(m, "error.database", err))
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, crosspostStatusEmbed(m, translate(m, "group.resumed_all"), translate(m, "group.all"), formatBool(m, true)))
+	return nil
+}
+
+func toggleGroup(s *discordgo.Session, m *discordgo.MessageCreate, editor groupEditor, name string) error {
+	var enabled bool
+	err := editor(name, func(g *database.Group) error {
+		enabled = !g.Active()
+		g.Disabled = !enabled
+		g.PausedUntil = time.Time{}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, crosspostStatusEmbed(m, translate(m, "group.toggled"), name, formatBool(m, enabled)))
+	return nil
+}
+
+func pauseGroup(s *discordgo.Session, m *discordgo.MessageCreate, editor groupEditor, name string, dur time.Duration) error {
+	until := time.Now().Add(dur)
+	err := editor(name, func(g *database.Group) error {
+		g.Disabled = false
+		g.PausedUntil = until
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, crosspostStatusEmbed(m, translate(m, "group.paused"), name, translate(m, "group.paused_until", until.UTC().Format(pauseLayout))))
+	return nil
+}
+
+func resumeGroup(s *discordgo.Session, m *discordgo.MessageCreate, editor groupEditor, name string) error {
+	err := editor(name, func(g *database.Group) error {
+		g.Disabled = false
+		g.PausedUntil = time.Time{}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, crosspostStatusEmbed(m, translate(m, "group.resumed"), name, formatBool(m, true)))
+	return nil
+}
+
+//serverChannels parses channel arguments of server group commands. Channels must belong to the server and be writable.
+func serverChannels(s *discordgo.Session, m *discordgo.MessageCreate, args []string) ([]string, error) {
+	channels := make([]string, 0, len(args))
+	for _, arg := range args {
+		id := strings.Trim(arg, "<#>")
+		ch, err := s.State.Channel(id)
+		if err != nil {
+			return nil, errors.New(translate(m, "group.unknown_channel", id))
+		}
+
+		if ch.GuildID != m.GuildID {
+			return nil, errors.New(translate(m, "server.foreign_channel", id))
+		}
+
+		if err := utils.CanCrosspost(s, m.Author.ID, id); err != nil {
+			return nil, fmt.Errorf("<#%v>: %v", id, err)
+		}
+		channels = append(channels, id)
+	}
+
+	return channels, nil
+}
+
+func sourceGroup(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) < 3 {
+		return errors.New(translate(m, "group.source_usage"))
+	}
+
+	channels := make([]string, 0, len(args)-2)
+	for _, arg := range args[2:] {
+		id := strings.Trim(arg, "<#>")
+		if _, err := s.State.Channel(id); err != nil {
+			return errors.New(translate(m, "group.unknown_channel", id))
+		}
+
+		if err := utils.CanCrosspost(s, m.Author.ID, id); err != nil {
+			return fmt.Errorf("<#%v>: %v", id, err)
+		}
+		channels = append(channels, id)
+	}
+
+	return editSources(s, m, userGroupEditor(m.Author.ID), args[0], args[1], channels)
+}
+
+//editSources adds or removes additional source channels of a group.
+func editSources(s *discordgo.Session, m *discordgo.MessageCreate, editor groupEditor, name, action string, channels []string) error {
+	changed := make([]string, 0)
+	err := editor(name, func(g *database.Group) error {
+		switch action {
+		case "add", "push":
+			exists := make(map[string]bool)
+			for _, c := range g.Members() {
+				exists[c] = true
+			}
+
+			for _, c := range channels {
+				if !exists[c] {
+					exists[c] = true
+					changed = append(changed, c)
+				}
+			}
+			g.Parents = append(g.Parents, changed...)
+		case "remove", "pop":
+			remove := make(map[string]bool)
+			for _, c := range channels {
+				remove[c] = true
+			}
+
+			parents := make([]string, 0, len(g.Parents))
+			for _, p := range g.Parents {
+				if remove[p] {
+					changed = append(changed, p)
+				} else {
+					parents = append(parents, p)
+				}
+			}
+			g.Parents = parents
+		default:
+			return errors.New(translate(m, "group.bad_source_action", action))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(changed) == 0 {
+		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+			Title:     translate(m, "group.sources_failed"),
+			Color:     utils.EmbedColor,
+			Timestamp: utils.EmbedTimestamp(),
+			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "group.group_name"), Value: name}, {Name: translate(m, "command.reason"), Value: translate(m, "group.no_channels")}},
+		})
+		return nil
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+		Title:     translate(m, "group.sources_edited"),
+		Color:     utils.EmbedColor,
+		Timestamp: utils.EmbedTimestamp(),
+		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+		Fields: []*discordgo.MessageEmbedField{{Name: translate(m, "group.group_name"), Value: name}, {Name: translate(m, "group.channels"), Value: strings.Join(utils.Map(changed, func(s string) string {
+			return fmt.Sprintf("<#%v>", s)
+		}), " ")}},
+	})
+	return nil
+}
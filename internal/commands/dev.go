@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/VTGare/boe-tea-go/internal/database"
+	"github.com/VTGare/boe-tea-go/internal/ugoira"
 	"github.com/VTGare/boe-tea-go/internal/widget"
 	"github.com/VTGare/boe-tea-go/utils"
 	"github.com/VTGare/gumi"
@@ -134,6 +135,7 @@ func devstats(s *discordgo.Session, m *discordgo.MessageCreate, args []string) e
 				Inline: false,
 			},
 			{Name: "RAM used", Value: fmt.Sprintf("%v MB", mem.Alloc/1024/1024), Inline: false},
+			{Name: "Ugoira queue", Value: ugoira.RenderQueue.String(), Inline: false},
 		},
 	})
 	return nil
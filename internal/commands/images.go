@@ -13,7 +13,9 @@ import (
 
 	"github.com/VTGare/boe-tea-go/internal/database"
 	"github.com/VTGare/boe-tea-go/internal/images"
+	"github.com/VTGare/boe-tea-go/internal/prompt"
 	"github.com/VTGare/boe-tea-go/internal/repost"
+	"github.com/VTGare/boe-tea-go/internal/ugoira"
 	"github.com/VTGare/boe-tea-go/internal/widget"
 	"github.com/VTGare/boe-tea-go/pkg/chotto"
 	"github.com/VTGare/boe-tea-go/pkg/seieki"
@@ -51,16 +53,20 @@ func init() {
 
 	sauceCmd := ig.AddCommand(&gumi.Command{
 		Name:        "sauce",
-		Description: "Finds source of an anime picture using SauceNAO",
+		Description: "Finds source of an anime picture using server's reverse search engine, SauceNAO by default",
 		Aliases:     []string{"saucenao", "snao"},
-		Exec:        saucenao,
+		Exec:        sauce,
 		Cooldown:    5 * time.Second,
 		Help:        gumi.NewHelpSettings(),
 	})
 	sauceCmd.Help.ExtendedHelp = []*discordgo.MessageEmbedField{
 		{
 			Name:  "Usage",
-			Value: "bt!sauce <image link>",
+			Value: "bt!sauce [engine] <image link>",
+		},
+		{
+			Name:  "engine",
+			Value: "Optional. ***saucenao*** or ***wait***, overrides server's ``reversesearch`` setting.",
 		},
 		{
 			Name:  "image link",
@@ -72,7 +78,7 @@ func init() {
 		Name:        "wait",
 		Description: "Finds an anime source from a screenshot.",
 		Aliases:     []string{"trace", "tracemoe"},
-		Exec:        saucenao,
+		Exec:        wait,
 		Cooldown:    10 * time.Second,
 		Help:        gumi.NewHelpSettings(),
 	})
@@ -107,6 +113,16 @@ func init() {
 	includeCmd.Help = gumi.NewHelpSettings().AddField("Usage", "bt!exclude <post link> [optional excluded images]", false)
 	excludeCmd.Help.AddField("included images", "Integer numbers separated by whitespace (e.g. 1 3 5). Supports ranges like this 1-10. Ranges are inclusive.", false)
 
+	ugoiraCmd := ig.AddCommand(&gumi.Command{
+		Name:        "ugoira",
+		Description: "Reposts a Pixiv animation in a chosen format.",
+		Aliases:     []string{"ugo"},
+		Exec:        ugoiraRepost,
+		Cooldown:    10 * time.Second,
+	})
+	ugoiraCmd.Help = gumi.NewHelpSettings().AddField("Usage", "bt!ugoira <post link> [optional format]", false)
+	ugoiraCmd.Help.AddField("format", "One of the following: ***mp4, gif, webm, apng***. Uses server's default format if omitted.", false)
+
 	dfCmd := ig.AddCommand(&gumi.Command{
 		Name:        "deepfry",
 		Description: "Deepfries an image, itadakimasu!",
@@ -148,6 +164,26 @@ func init() {
 		Help:        gumi.NewHelpSettings(),
 	})
 	crosspostCmd.Help.AddField("Usage", "bt!crosspost <twitter or pixiv link> [excluded channels]", false).AddField("Excluded channels", "IDs or mentions of channels you'd like to exclude from crossposting. Omit argument or give ``all`` to skip crossposting", false)
+	crosspostCmd.Help.AddField("Backup", "``bt!crosspost export`` sends your cross-post groups as a JSON file. ``bt!crosspost import [merge | replace]`` with an attached file restores them.", false)
+}
+
+//sauce searches an image with an engine given as the first argument or with server's default one.
+func sauce(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	engine := "saucenao"
+	if guild := database.Settings(m.GuildID, m.ChannelID); guild != nil {
+		engine = guild.SearchEngine()
+	}
+
+	if len(args) > 0 && (args[0] == "saucenao" || args[0] == "wait") {
+		engine = args[0]
+		args = args[1:]
+	}
+
+	if engine == "wait" {
+		return wait(s, m, args)
+	}
+
+	return saucenao(s, m, args)
 }
 
 func saucenao(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
@@ -161,7 +197,7 @@ func saucenao(s *discordgo.Session, m *discordgo.MessageCreate, args []string) e
 	}
 
 	log.Infof("Searching source on SauceNAO. Image URL: %s", url)
-	embeds, err := saucenaoEmbeds(url)
+	embeds, err := saucenaoEmbeds(url, database.GuildTemplate(m.GuildID, database.SauceEmbed))
 	if err != nil {
 		return err
 	}
@@ -193,7 +229,7 @@ func wait(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error
 	}
 
 	log.Infof("Searching source on trace.moe. Image URL: %s", url)
-	embed, err := waitEmbed(url)
+	embed, err := waitEmbed(url, database.GuildTemplate(m.GuildID, database.SauceEmbed))
 	if err != nil {
 		return err
 	}
@@ -308,7 +344,7 @@ func joinSauceURLs(urls []string, sep string) string {
 	return sb.String()
 }
 
-func saucenaoEmbeds(link string) ([]*discordgo.MessageEmbed, error) {
+func saucenaoEmbeds(link string, template *database.EmbedTemplate) ([]*discordgo.MessageEmbed, error) {
 	res, err := sei.Sauce(link)
 	if err != nil && res == nil {
 		return nil, err
@@ -325,22 +361,20 @@ func saucenaoEmbeds(link string) ([]*discordgo.MessageEmbed, error) {
 
 	embeds := make([]*discordgo.MessageEmbed, l)
 	for ind, source := range res.Results {
-		embed := saucenaoToEmbed(source, ind, l)
+		embed := saucenaoToEmbed(source, ind, l, template)
 		if s := source.URL(); s != "" {
 			if _, err := url.ParseRequestURI(embed.URL); err != nil && len(source.Data.URLs) > 0 {
 				embed.URL = source.Data.URLs[0]
 			}
-		} else {
-			embed.Fields = embed.Fields[1:]
 		}
 
-		embeds[ind] = embed
+		embeds[ind] = template.Apply(embed)
 	}
 
 	return embeds, nil
 }
 
-func saucenaoToEmbed(source *seieki.Sauce, index, lenght int) *discordgo.MessageEmbed {
+func saucenaoToEmbed(source *seieki.Sauce, index, lenght int, template *database.EmbedTemplate) *discordgo.MessageEmbed {
 	title := ""
 	if lenght > 1 {
 		title = fmt.Sprintf("[%v/%v] Title: %v", index+1, lenght, source.Title())
@@ -348,35 +382,36 @@ func saucenaoToEmbed(source *seieki.Sauce, index, lenght int) *discordgo.Message
 		title = fmt.Sprintf("Title: %v", source.Title())
 	}
 
-	return &discordgo.MessageEmbed{
+	embed := &discordgo.MessageEmbed{
 		Title:     title,
 		Timestamp: utils.EmbedTimestamp(),
 		Color:     utils.EmbedColor,
 		Thumbnail: &discordgo.MessageEmbedThumbnail{
 			URL: source.Header.Thumbnail,
 		},
-		Fields: []*discordgo.MessageEmbedField{
-			{
-				Name:  "Source",
-				Value: source.URL(),
-			},
-			{
-				Name:  "URLs",
-				Value: joinSauceURLs(source.Data.URLs, " • "),
-			},
-			{
-				Name:  "Similarity",
-				Value: source.Header.Similarity,
-			},
-			{
-				Name:  "Author",
-				Value: source.Author(),
-			},
-		},
+		Fields: make([]*discordgo.MessageEmbedField, 0, 4),
 	}
+
+	if template.Shows("source") && source.URL() != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Source", Value: source.URL()})
+	}
+
+	if template.Shows("urls") {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "URLs", Value: joinSauceURLs(source.Data.URLs, " • ")})
+	}
+
+	if template.Shows("similarity") {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Similarity", Value: source.Header.Similarity})
+	}
+
+	if template.Shows("author") {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Author", Value: source.Author()})
+	}
+
+	return embed
 }
 
-func waitEmbed(link string) (*discordgo.MessageEmbed, error) {
+func waitEmbed(link string, template *database.EmbedTemplate) (*discordgo.MessageEmbed, error) {
 	res, err := chotto.SearchWait(link)
 	if err != nil {
 		return nil, err
@@ -409,23 +444,22 @@ func waitEmbed(link string) (*discordgo.MessageEmbed, error) {
 		Description: description,
 		Color:       utils.EmbedColor,
 		Timestamp:   utils.EmbedTimestamp(),
-		Fields: []*discordgo.MessageEmbedField{
-			{
-				Name:  "Similarity",
-				Value: fmt.Sprintf("%v%%", anime.Similarity*100),
-			},
-			{
-				Name:  "Timestamp",
-				Value: fmt.Sprintf("%v", readableSeconds(anime.At)),
-			},
-			{
-				Name:  "Episode",
-				Value: fmt.Sprintf("%v", anime.Episode),
-			},
-		},
+		Fields:      make([]*discordgo.MessageEmbedField, 0, 3),
+	}
+
+	if template.Shows("similarity") {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Similarity", Value: fmt.Sprintf("%v%%", anime.Similarity*100)})
+	}
+
+	if template.Shows("timestamp") {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Timestamp", Value: fmt.Sprintf("%v", readableSeconds(anime.At))})
+	}
+
+	if template.Shows("episode") {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Episode", Value: fmt.Sprintf("%v", anime.Episode)})
 	}
 
-	return embed, nil
+	return template.Apply(embed), nil
 }
 
 func readableSeconds(sec float64) string {
@@ -444,7 +478,7 @@ func exclude(s *discordgo.Session, m *discordgo.MessageCreate, args []string) er
 
 	art := repost.NewPost(m, url)
 	if art.Len() == 0 {
-		return errors.New("First argument **must** be a Pixiv link.")
+		return errors.New(translate(m, "pixiv.link_required"))
 	}
 
 	indexMap := make(map[int]bool)
@@ -475,9 +509,8 @@ func exclude(s *discordgo.Session, m *discordgo.MessageCreate, args []string) er
 		return err
 	}
 
-	if user := database.DB.FindUser(m.Author.ID); user != nil {
-		channels := user.Channels(m.ChannelID)
-		err := art.Crosspost(s, channels, opts)
+	if targets := database.CrosspostTargets(m.GuildID, m.Author.ID, m.ChannelID); len(targets) > 0 {
+		err := art.Crosspost(s, targets, opts)
 		if err != nil {
 			return err
 		}
@@ -498,7 +531,7 @@ func include(s *discordgo.Session, m *discordgo.MessageCreate, args []string) er
 
 	art := repost.NewPost(m, url)
 	if art.Len() == 0 {
-		return errors.New("First argument **must** be a Pixiv link.")
+		return errors.New(translate(m, "pixiv.link_required"))
 	}
 
 	indexMap := make(map[int]bool)
@@ -530,9 +563,42 @@ func include(s *discordgo.Session, m *discordgo.MessageCreate, args []string) er
 		return err
 	}
 
-	if user := database.DB.FindUser(m.Author.ID); user != nil {
-		channels := user.Channels(m.ChannelID)
-		err := art.Crosspost(s, channels, opts)
+	if targets := database.CrosspostTargets(m.GuildID, m.Author.ID, m.ChannelID); len(targets) > 0 {
+		err := art.Crosspost(s, targets, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ugoiraRepost(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) == 0 {
+		return utils.ErrNotEnoughArguments
+	}
+
+	art := repost.NewPost(m, args[0])
+	if len(art.PixivMatches) == 0 {
+		return errors.New(translate(m, "pixiv.link_required"))
+	}
+
+	opts := repost.SendPixivOptions{}
+	if len(args) > 1 {
+		format, err := ugoira.ParseFormat(args[1])
+		if err != nil {
+			return err
+		}
+		opts.UgoiraFormat = format
+	}
+
+	err := art.Post(s, opts)
+	if err != nil {
+		return err
+	}
+
+	if targets := database.CrosspostTargets(m.GuildID, m.Author.ID, m.ChannelID); len(targets) > 0 {
+		err := art.Crosspost(s, targets, opts)
 		if err != nil {
 			return err
 		}
@@ -546,11 +612,27 @@ func crosspost(s *discordgo.Session, m *discordgo.MessageCreate, args []string)
 		return fmt.Errorf("bt!crosspost requires at least one argument. **Usage:** bt!crosspost <pixiv link> [channel IDs]")
 	}
 
+	switch args[0] {
+	case "export":
+		return exportGroups(s, m, args[1:])
+	case "import":
+		return importGroups(s, m, args[1:])
+	}
+
 	var (
-		user = database.DB.FindUser(m.Author.ID)
-		art  = repost.NewPost(m)
+		targets = database.CrosspostTargets(m.GuildID, m.Author.ID, m.ChannelID)
+		art     = repost.NewPost(m)
 	)
-	if user == nil {
+	if len(targets) == 0 {
+		if user := database.DB.FindUser(m.Author.ID); user != nil {
+			switch {
+			case !user.Crosspost:
+				return errors.New(translate(m, "crosspost.off"))
+			case !user.CrosspostEnabled():
+				return errors.New(translate(m, "crosspost.paused", user.PausedUntil.UTC().Format(pauseLayout)))
+			}
+		}
+
 		return fmt.Errorf("You have no cross-post groups. Please create one using a following command: ``bt!create <group name> <parent id>``")
 	}
 
@@ -563,16 +645,19 @@ func crosspost(s *discordgo.Session, m *discordgo.MessageCreate, args []string)
 			return nil
 		}
 
-		channels := utils.Filter(user.Channels(m.ChannelID), func(str string) bool {
-			for _, a := range args[1:] {
-				a = strings.Trim(a, "<#>")
-				if a == str {
-					return false
-				}
+		excluded := make(map[string]bool)
+		for _, a := range args[1:] {
+			excluded[strings.Trim(a, "<#>")] = true
+		}
+
+		filtered := make([]*database.Target, 0, len(targets))
+		for _, t := range targets {
+			if !excluded[t.ChannelID] {
+				filtered = append(filtered, t)
 			}
-			return true
-		})
-		err := art.Crosspost(s, channels)
+		}
+
+		err := art.Crosspost(s, filtered)
 		if err != nil {
 			return err
 		}
@@ -586,7 +671,7 @@ func twitter(s *discordgo.Session, m *discordgo.MessageCreate, args []string) er
 		return utils.ErrNotEnoughArguments
 	}
 
-	guild := database.GuildCache[m.GuildID]
+	guild := database.Settings(m.GuildID, m.ChannelID)
 	a := repost.NewPost(m, args[0])
 
 	if guild.Repost != "disabled" {
@@ -597,10 +682,10 @@ func twitter(s *discordgo.Session, m *discordgo.MessageCreate, args []string) er
 				s.ChannelMessageSendEmbed(m.ChannelID, a.RepostEmbed(reposts))
 				return nil
 			case "enabled":
-				f := utils.CreatePromptWithMessage(s, m, &discordgo.MessageSend{
-					Content: "Tweet you're trying to post is a repost. Are you sure about that?",
+				f := prompt.CreateWithMessage(s, m, &discordgo.MessageSend{
+					Content: translate(m, "repost.tweet_repost"),
 					Embed:   a.RepostEmbed(reposts),
-				})
+				}, guild.ConfirmEmoji())
 				if !f {
 					return nil
 				}
@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/VTGare/boe-tea-go/internal/database"
+	"github.com/VTGare/boe-tea-go/internal/locale"
+	"github.com/VTGare/boe-tea-go/utils"
+	"github.com/VTGare/gumi"
+	"github.com/bwmarrin/discordgo"
+)
+
+//localizedError carries preferred locales of command's author to the error handler.
+type localizedError struct {
+	err     error
+	locales []string
+}
+
+func (e *localizedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *localizedError) Unwrap() error {
+	return e.err
+}
+
+//errorKeys are catalogue keys of common errors.
+var errorKeys = map[error]string{
+	utils.ErrNotEnoughArguments: "error.not_enough_arguments",
+	utils.ErrParsingArgument:    "error.parsing_argument",
+	utils.ErrNoPermission:       "error.no_permission",
+}
+
+var localizeOnce sync.Once
+
+//LocalizeErrors makes errors of all commands carry their author's locales, so the error handler can respond in their language.
+//It must be called once every command has been added.
+func LocalizeErrors() {
+	localizeOnce.Do(func() {
+		seen := make(map[*gumi.Command]bool)
+		for _, g := range Router.Groups {
+			for _, cmd := range g.Commands {
+				//aliases share a command
+				if seen[cmd] {
+					continue
+				}
+				seen[cmd] = true
+
+				exec := cmd.Exec
+				cmd.Exec = func(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+					if err := exec(s, m, args); err != nil {
+						return &localizedError{err, database.Locales(m.GuildID, m.Author.ID)}
+					}
+					return nil
+				}
+			}
+		}
+	})
+}
+
+//errorMessage creates an error embed in the language of command's author, English is used for errors without one.
+func errorMessage(e error) *discordgo.MessageSend {
+	if e == nil {
+		return nil
+	}
+
+	var (
+		locales []string
+		le      *localizedError
+		message = e.Error()
+	)
+
+	if errors.As(e, &le) {
+		locales = le.locales
+		if key, ok := errorKeys[le.err]; ok {
+			message = locale.Get(locales, key)
+		}
+	}
+
+	return &discordgo.MessageSend{
+		Embed: &discordgo.MessageEmbed{
+			Title: locale.Get(locales, "error.title"),
+			Thumbnail: &discordgo.MessageEmbedThumbnail{
+				URL: utils.DefaultEmbedImage,
+			},
+			Description: locale.Get(locales, "error.description", message),
+			Color:       utils.EmbedColor,
+			Timestamp:   utils.EmbedTimestamp(),
+		},
+	}
+}
+
+//translate returns a message in the language of command's author.
+func translate(m *discordgo.MessageCreate, key string, args ...interface{}) string {
+	return locale.Get(database.Locales(m.GuildID, m.Author.ID), key, args...)
+}
+
+//formatBool formats a boolean as enabled or disabled in the language of command's author.
+func formatBool(m *discordgo.MessageCreate, b bool) string {
+	if b {
+		return translate(m, "settings.enabled")
+	}
+	return translate(m, "settings.disabled")
+}
+
+//setHelpFields are sections of set command's extended help. Sections without a name are named by the catalogue.
+var setHelpFields = []struct {
+	name string
+	key  string
+}{
+	{"", "usage"},
+	{"", "managers"},
+	{"", "embed"},
+	{"", "footers"},
+	{"", "history"},
+	{"", "channel"},
+	{"prefix", "prefix"},
+	{"footer", "footer"},
+	{"largeset", "largeset"},
+	{"limit", "limit"},
+	{"locale", "locale"},
+	{"pixiv | twitter", "pixiv"},
+	{"repost", "repost"},
+	{"ugoira", "ugoira"},
+	{"reversesearch", "reversesearch"},
+	{"promptemoji", "promptemoji"},
+}
+
+//setHelp returns set command's extended help in the first available of given locales.
+func setHelp(locales []string) []*discordgo.MessageEmbedField {
+	fields := make([]*discordgo.MessageEmbedField, 0, len(setHelpFields))
+	for _, f := range setHelpFields {
+		name := f.name
+		if name == "" {
+			name = locale.Get(locales, "help.set."+f.key+".name")
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{Name: name, Value: locale.Get(locales, "help.set."+f.key)})
+	}
+
+	return fields
+}
+
+//localizedHelp translates extended help of commands with localised help.
+func localizedHelp(help gumi.HelpHandler) gumi.HelpHandler {
+	return func(g *gumi.Gumi, s *discordgo.Session, m *discordgo.MessageCreate, args []string) *discordgo.MessageSend {
+		send := help(g, s, m, args)
+		if len(args) == 0 || send == nil || send.Embed == nil {
+			return send
+		}
+
+		if cmd := findCommand(args[len(args)-1]); cmd != nil && cmd.Name == "set" {
+			send.Embed.Fields = setHelp(database.Locales(m.GuildID, m.Author.ID))
+		}
+
+		return send
+	}
+}
+
+func setLocale(s *discordgo.Session, m *discordgo.MessageCreate, str string) (interface{}, error) {
+	if !locale.IsSupported(str) {
+		return nil, errors.New(locale.Get(database.Locales(m.GuildID, m.Author.ID), "locale.unsupported", str, strings.Join(locale.Supported(), ", ")))
+	}
+	return str, nil
+}
+
+//userLocale shows or changes command author's own language.
+func userLocale(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) > 0 {
+		code := args[0]
+		switch code {
+		case "reset", "server", "default":
+			code = ""
+		default:
+			if !locale.IsSupported(code) {
+				return errors.New(locale.Get(database.Locales(m.GuildID, m.Author.ID), "locale.unsupported", code, strings.Join(locale.Supported(), ", ")))
+			}
+		}
+
+		if err := database.DB.SetLocale(m.Author.ID, code); err != nil {
+			return fmt.Errorf("Fatal database error: %v", err)
+		}
+	}
+
+	var (
+		locales = database.Locales(m.GuildID, m.Author.ID)
+		current = locale.Get(locales, "locale.name")
+		title   = locale.Get(locales, "locale.title")
+	)
+
+	if user := database.DB.FindUser(m.Author.ID); user == nil || user.Locale == "" {
+		current += fmt.Sprintf(" (%v)", locale.Get(locales, "locale.server"))
+	}
+	if len(args) > 0 {
+		title = locale.Get(locales, "locale.changed")
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+		Title: title,
+		Color: utils.EmbedColor,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: locale.Get(locales, "locale.current"), Value: current, Inline: true},
+			{Name: locale.Get(locales, "locale.available"), Value: strings.Join(locale.Supported(), ", "), Inline: true},
+		},
+		Timestamp: utils.EmbedTimestamp(),
+	})
+	return nil
+}
@@ -51,14 +51,14 @@ func nhentai(s *discordgo.Session, m *discordgo.MessageCreate, args []string) er
 		return utils.ErrNotEnoughArguments
 	}
 
-	g := database.GuildCache[m.GuildID]
+	g := database.Settings(m.GuildID, m.ChannelID)
 	if !g.NSFW {
 		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-			Title:     "❎ Failed to execute a command.",
+			Title:     translate(m, "command.failed"),
 			Color:     utils.EmbedColor,
 			Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
 			Timestamp: utils.EmbedTimestamp(),
-			Fields:    []*discordgo.MessageEmbedField{{"Reason", "You're trying to execute an NSFW command. The server prohibits NSFW content.", false}},
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "command.reason"), Value: translate(m, "nsfw.command")}},
 		})
 		return nil
 	}
@@ -3,19 +3,27 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
 
 	"github.com/VTGare/boe-tea-go/internal/database"
+	"github.com/VTGare/boe-tea-go/internal/locale"
+	"github.com/VTGare/boe-tea-go/internal/prompt"
+	"github.com/VTGare/boe-tea-go/internal/ugoira"
+	"github.com/VTGare/boe-tea-go/internal/widget"
 	"github.com/VTGare/boe-tea-go/utils"
 	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
 )
 
 type settingFunc func(*discordgo.Session, *discordgo.MessageCreate, string) (interface{}, error)
 
 var (
-	settingMap = make(map[string]settingFunc)
+	settingMap       = make(map[string]settingFunc)
+	customEmojiRegex = regexp.MustCompile(`^<a?:(\w+):(\d+)>$`)
 )
 
 func init() {
@@ -27,20 +35,57 @@ func init() {
 	settingMap["prefix"] = setPrefix
 	settingMap["limit"] = setInt
 	settingMap["repost"] = setRepost
+	settingMap["ugoira"] = setUgoira
+	settingMap["largeset"] = setLargeSet
+	settingMap["reversesearch"] = setReverseSearch
+	settingMap["promptemoji"] = setPromptEmoji
+	settingMap["footer"] = setFooterMode
+	settingMap["locale"] = setLocale
 }
 
 func set(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) > 0 && args[0] == "--channel" {
+		return setChannel(s, m, args[1:])
+	}
+
+	if len(args) > 0 && args[0] == "managers" {
+		return setManagers(s, m, args[1:])
+	}
+
+	if len(args) > 0 && args[0] == "embed" {
+		return setEmbed(s, m, args[1:])
+	}
+
+	if len(args) > 0 && args[0] == "footers" {
+		return setFooters(s, m, args[1:])
+	}
+
+	if len(args) > 0 && (args[0] == "history" || args[0] == "rollback") {
+		isManager, err := isBotManager(s, m)
+		if err != nil {
+			return err
+		}
+		if !isManager {
+			return utils.ErrNoPermission
+		}
+
+		if args[0] == "history" {
+			return settingsHistory(s, m)
+		}
+		return rollbackSetting(s, m, args[1:])
+	}
+
 	settings := database.GuildCache[m.GuildID]
 
 	switch len(args) {
 	case 0:
 		showGuildSettings(s, m, settings)
 	case 2:
-		isAdmin, err := utils.MemberHasPermission(s, m.GuildID, m.Author.ID, discordgo.PermissionAdministrator)
+		isManager, err := isBotManager(s, m)
 		if err != nil {
 			return err
 		}
-		if !isAdmin {
+		if !isManager {
 			return utils.ErrNoPermission
 		}
 
@@ -57,20 +102,21 @@ func set(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error
 			if err != nil {
 				return err
 			}
-			err = database.DB.ChangeSetting(m.GuildID, setting, n)
+			err = database.DB.ChangeSetting(m.GuildID, m.Author.ID, setting, n)
 			if err != nil {
 				return err
 			}
+			locales := database.Locales(m.GuildID, m.Author.ID)
 			embed := &discordgo.MessageEmbed{
-				Title: "✅ Successfully changed a setting!",
+				Title: locale.Get(locales, "settings.changed"),
 				Fields: []*discordgo.MessageEmbedField{
 					{
-						Name:   "Setting",
+						Name:   locale.Get(locales, "settings.setting"),
 						Value:  setting,
 						Inline: true,
 					},
 					{
-						Name:   "New value",
+						Name:   locale.Get(locales, "settings.new_value"),
 						Value:  newSetting,
 						Inline: true,
 					},
@@ -80,47 +126,707 @@ func set(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error
 			}
 			s.ChannelMessageSendEmbed(m.ChannelID, embed)
 		} else {
-			return fmt.Errorf("invalid setting name: %v", setting)
+			return errors.New(translate(m, "settings.bad_name", setting))
+		}
+	default:
+		return errors.New(translate(m, "settings.bad_usage"))
+	}
+
+	return nil
+}
+
+func settingsHistory(s *discordgo.Session, m *discordgo.MessageCreate) error {
+	changes, err := database.DB.SettingsHistory(m.GuildID, 100)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+			Title:       translate(m, "history.title"),
+			Description: translate(m, "history.empty"),
+			Color:       utils.EmbedColor,
+			Timestamp:   utils.EmbedTimestamp(),
+		})
+		return nil
+	}
+
+	var (
+		perPage = 10
+		pages   = make([]*discordgo.MessageEmbed, 0, len(changes)/perPage+1)
+	)
+
+	for start := 0; start < len(changes); start += perPage {
+		lines := make([]string, 0, perPage)
+		for _, change := range changes[start:utils.Min(start+perPage, len(changes))] {
+			lines = append(lines, translate(m, "history.entry", change.Seq, change.Setting, change.UserID, change.CreatedAt.UTC().Format(pauseLayout), formatSettingValue(m, change.OldValue), formatSettingValue(m, change.NewValue)))
+		}
+
+		pages = append(pages, &discordgo.MessageEmbed{
+			Title:       translate(m, "history.title"),
+			Description: strings.Join(lines, "\n"),
+			Color:       utils.EmbedColor,
+			Footer:      &discordgo.MessageEmbedFooter{Text: translate(m, "history.footer", start/perPage+1, (len(changes)+perPage-1)/perPage)},
+			Timestamp:   utils.EmbedTimestamp(),
+		})
+	}
+
+	if len(pages) == 1 {
+		_, err := s.ChannelMessageSendEmbed(m.ChannelID, pages[0])
+		return err
+	}
+
+	return widget.NewWidget(s, m.Author.ID, pages).Start(m.ChannelID)
+}
+
+func rollbackSetting(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) == 0 {
+		return errors.New(translate(m, "history.entry_required"))
+	}
+
+	entry, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+	if err != nil {
+		return utils.ErrParsingArgument
+	}
+
+	change, err := database.DB.FindSettingChange(m.GuildID, entry)
+	if err != nil {
+		return errors.New(translate(m, "history.not_found", entry))
+	}
+
+	//rollback is a change of the same setting and requires the same permissions
+	if change.Setting == "manager_roles" {
+		isAdmin, err := utils.MemberHasPermission(s, m.GuildID, m.ChannelID, m.Author.ID, discordgo.PermissionAdministrator)
+		if err != nil {
+			return err
+		}
+		if !isAdmin {
+			return utils.ErrNoPermission
+		}
+	}
+
+	if err := database.DB.RollbackSetting(m.GuildID, m.Author.ID, change); err != nil {
+		return err
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+		Title: translate(m, "history.rolled_back"),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: translate(m, "settings.setting"), Value: change.Setting, Inline: true},
+			{Name: translate(m, "history.restored"), Value: formatSettingValue(m, change.OldValue), Inline: true},
+		},
+		Color:     utils.EmbedColor,
+		Timestamp: utils.EmbedTimestamp(),
+	})
+	return nil
+}
+
+//formatSettingValue formats a setting value from history. Long values such as cross-post groups are shortened.
+func formatSettingValue(m *discordgo.MessageCreate, value interface{}) string {
+	if value == nil {
+		return translate(m, "settings.unset")
+	}
+
+	str := fmt.Sprintf("%v", value)
+	if runes := []rune(str); len(runes) > 100 {
+		str = string(runes[:97]) + "..."
+	}
+
+	return "``" + strings.ReplaceAll(str, "`", "'") + "``"
+}
+
+//isBotManager checks if a message author is an administrator or has one of server's bot manager roles.
+func isBotManager(s *discordgo.Session, m *discordgo.MessageCreate) (bool, error) {
+	var roles []string
+	if guild, ok := database.GuildCache[m.GuildID]; ok {
+		roles = guild.ManagerRoles
+	}
+
+	return utils.IsBotManager(s, m.GuildID, m.ChannelID, m.Author.ID, roles)
+}
+
+//setManagers shows or changes bot manager roles. Only administrators can change them.
+func setManagers(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	settings := database.GuildCache[m.GuildID]
+	if len(args) == 0 {
+		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+			Title:     translate(m, "managers.title"),
+			Color:     utils.EmbedColor,
+			Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "managers.roles"), Value: managerRoles(m, settings)}},
+			Timestamp: utils.EmbedTimestamp(),
+		})
+		return nil
+	}
+
+	if len(args) < 2 || (args[0] != "add" && args[0] != "remove") {
+		return errors.New(translate(m, "settings.bad_usage"))
+	}
+
+	isAdmin, err := utils.MemberHasPermission(s, m.GuildID, m.ChannelID, m.Author.ID, discordgo.PermissionAdministrator)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return utils.ErrNoPermission
+	}
+
+	roles := make(map[string]bool)
+	for _, id := range settings.ManagerRoles {
+		roles[id] = true
+	}
+
+	for _, arg := range args[1:] {
+		id := strings.Trim(arg, "<@&>")
+
+		//deleted roles can still be removed from the list
+		if args[0] == "add" {
+			if _, err := s.State.Role(m.GuildID, id); err != nil {
+				return errors.New(translate(m, "managers.bad_role", id))
+			}
+		}
+
+		roles[id] = args[0] == "add"
+	}
+
+	managers := make([]string, 0, len(roles))
+	for id, ok := range roles {
+		if ok {
+			managers = append(managers, id)
+		}
+	}
+	sort.Strings(managers)
+
+	if err := database.DB.ChangeSetting(m.GuildID, m.Author.ID, "manager_roles", managers); err != nil {
+		return err
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+		Title:     translate(m, "managers.changed"),
+		Color:     utils.EmbedColor,
+		Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "managers.roles"), Value: managerRoles(m, database.GuildCache[m.GuildID])}},
+		Timestamp: utils.EmbedTimestamp(),
+	})
+	return nil
+}
+
+func managerRoles(m *discordgo.MessageCreate, settings *database.GuildSettings) string {
+	if len(settings.ManagerRoles) == 0 {
+		return translate(m, "managers.admins_only")
+	}
+
+	return strings.Join(utils.Map(settings.ManagerRoles, func(id string) string {
+		return fmt.Sprintf("<@&%v>", id)
+	}), " ")
+}
+
+func embedTemplates(m *discordgo.MessageCreate, settings *database.GuildSettings) string {
+	if len(settings.EmbedTemplates) == 0 {
+		return translate(m, "embed.default_templates")
+	}
+
+	kinds := make([]string, 0, len(settings.EmbedTemplates))
+	for kind := range settings.EmbedTemplates {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	return translate(m, "embed.customised", strings.Join(kinds, ", "))
+}
+
+//setFooters shows, adds or removes server's own footer messages.
+func setFooters(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) == 0 {
+		return showFooters(s, m, database.GuildCache[m.GuildID])
+	}
+
+	isManager, err := isBotManager(s, m)
+	if err != nil {
+		return err
+	}
+	if !isManager {
+		return utils.ErrNoPermission
+	}
+
+	switch {
+	case args[0] == "add" && len(args) > 1:
+		footer := &database.FooterMessage{}
+		if args[1] == "--nsfw" {
+			footer.NSFW = true
+			args = args[1:]
+		}
+
+		if len(args) < 2 {
+			return errors.New(translate(m, "footers.required"))
+		}
+
+		//messages are case-sensitive, take the original from message's content
+		footer.Content = rawArgs(m, len(args)-1)
+		if len([]rune(footer.Content)) > 256 {
+			return errors.New(translate(m, "footers.too_long"))
+		}
+
+		if err := database.DB.AddFooter(m.GuildID, m.Author.ID, footer); err != nil {
+			return err
+		}
+
+		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+			Title:       translate(m, "footers.added"),
+			Description: footer.Content,
+			Color:       utils.EmbedColor,
+			Fields:      []*discordgo.MessageEmbedField{{Name: "NSFW", Value: formatBool(m, footer.NSFW), Inline: true}, {Name: translate(m, "footers.mode"), Value: database.GuildCache[m.GuildID].FooterMode(), Inline: true}},
+			Timestamp:   utils.EmbedTimestamp(),
+		})
+	case args[0] == "remove" && len(args) == 2:
+		index, err := strconv.Atoi(args[1])
+		if err != nil {
+			return utils.ErrParsingArgument
+		}
+
+		removed, err := database.DB.RemoveFooter(m.GuildID, m.Author.ID, index)
+		if err != nil {
+			return err
+		}
+
+		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+			Title:       translate(m, "footers.removed"),
+			Description: removed.Content,
+			Color:       utils.EmbedColor,
+			Timestamp:   utils.EmbedTimestamp(),
+		})
+	default:
+		return errors.New(translate(m, "settings.bad_usage"))
+	}
+
+	return nil
+}
+
+func showFooters(s *discordgo.Session, m *discordgo.MessageCreate, settings *database.GuildSettings) error {
+	if len(settings.Footers) == 0 {
+		_, err := s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+			Title:       translate(m, "footers.title"),
+			Description: translate(m, "footers.empty"),
+			Color:       utils.EmbedColor,
+			Fields:      []*discordgo.MessageEmbedField{{Name: translate(m, "footers.mode"), Value: settings.FooterMode()}},
+			Timestamp:   utils.EmbedTimestamp(),
+		})
+		return err
+	}
+
+	var (
+		perPage = 10
+		pages   = make([]*discordgo.MessageEmbed, 0, len(settings.Footers)/perPage+1)
+	)
+
+	for start := 0; start < len(settings.Footers); start += perPage {
+		lines := make([]string, 0, perPage)
+		for ind, f := range settings.Footers[start:utils.Min(start+perPage, len(settings.Footers))] {
+			line := fmt.Sprintf("**#%v** %v", start+ind+1, f.Content)
+			if f.NSFW {
+				line += " ``NSFW``"
+			}
+			lines = append(lines, line)
+		}
+
+		pages = append(pages, &discordgo.MessageEmbed{
+			Title:       translate(m, "footers.title"),
+			Description: strings.Join(lines, "\n"),
+			Color:       utils.EmbedColor,
+			Fields:      []*discordgo.MessageEmbedField{{Name: translate(m, "footers.mode"), Value: settings.FooterMode()}},
+			Footer:      &discordgo.MessageEmbedFooter{Text: translate(m, "footers.page", start/perPage+1, (len(settings.Footers)+perPage-1)/perPage)},
+			Timestamp:   utils.EmbedTimestamp(),
+		})
+	}
+
+	if len(pages) == 1 {
+		_, err := s.ChannelMessageSendEmbed(m.ChannelID, pages[0])
+		return err
+	}
+
+	return widget.NewWidget(s, m.Author.ID, pages).Start(m.ChannelID)
+}
+
+//setEmbed shows or changes server's embed templates.
+func setEmbed(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	settings := database.GuildCache[m.GuildID]
+	if len(args) == 0 {
+		fields := make([]*discordgo.MessageEmbedField, 0, len(database.EmbedFields))
+		for _, kind := range []string{database.PixivEmbed, database.TwitterEmbed, database.RepostEmbed, database.SauceEmbed} {
+			fields = append(fields, &discordgo.MessageEmbedField{Name: kind, Value: settings.Template(kind).Describe(database.Locales(m.GuildID, m.Author.ID))})
+		}
+
+		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+			Title:     translate(m, "embed.title"),
+			Color:     utils.EmbedColor,
+			Fields:    fields,
+			Timestamp: utils.EmbedTimestamp(),
+		})
+		return nil
+	}
+
+	kind := args[0]
+	available, ok := database.EmbedFields[kind]
+	if !ok {
+		return errors.New(translate(m, "embed.unknown", kind))
+	}
+
+	if len(args) == 1 {
+		s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+			Title: translate(m, "embed.kind_title", kind),
+			Color: settings.Template(kind).ColorOr(utils.EmbedColor),
+			Fields: []*discordgo.MessageEmbedField{
+				{Name: translate(m, "embed.template"), Value: settings.Template(kind).Describe(database.Locales(m.GuildID, m.Author.ID))},
+				{Name: translate(m, "embed.fields"), Value: strings.Join(available, ", ")},
+			},
+			Timestamp: utils.EmbedTimestamp(),
+		})
+		return nil
+	}
+
+	isManager, err := isBotManager(s, m)
+	if err != nil {
+		return err
+	}
+	if !isManager {
+		return utils.ErrNoPermission
+	}
+
+	property := args[1]
+	if property != "reset" && len(args) < 3 {
+		return errors.New(translate(m, "settings.bad_usage"))
+	}
+
+	var (
+		values = args[2:]
+		edit   func(*database.EmbedTemplate) error
+	)
+
+	switch property {
+	case "reset":
+		edit = func(t *database.EmbedTemplate) error {
+			*t = database.EmbedTemplate{}
+			return nil
+		}
+	case "color", "colour":
+		color, err := parseColor(values[0])
+		if err != nil {
+			return errors.New(translate(m, "embed.bad_color", values[0]))
+		}
+
+		edit = func(t *database.EmbedTemplate) error {
+			t.Color = color
+			return nil
+		}
+	case "thumbnail":
+		edit = func(t *database.EmbedTemplate) error {
+			t.Thumbnail, t.NoThumbnail = "", false
+			switch values[0] {
+			case "default":
+			case "none", "off":
+				t.NoThumbnail = true
+			default:
+				//URLs are case-sensitive, take the original from message's content
+				raw := rawArgs(m, 1)
+				if !ImageURLRegex.MatchString(raw) {
+					return errors.New(translate(m, "embed.bad_url", raw))
+				}
+				t.Thumbnail = raw
+			}
+			return nil
+		}
+	case "fields":
+		shown := make(map[string]bool)
+		for _, value := range values {
+			for _, field := range strings.Split(value, ",") {
+				if field != "" {
+					shown[field] = true
+				}
+			}
+		}
+
+		edit = func(t *database.EmbedTemplate) error {
+			t.HiddenFields = nil
+			if shown["all"] || shown["default"] {
+				return nil
+			}
+
+			for field := range shown {
+				if field != "none" && !utils.Contains(available, field) {
+					return errors.New(translate(m, "embed.unknown_field", field, strings.Join(available, ", ")))
+				}
+			}
+
+			for _, field := range available {
+				if !shown[field] {
+					t.HiddenFields = append(t.HiddenFields, field)
+				}
+			}
+			return nil
+		}
+	case "tags":
+		count := 0
+		if values[0] != "all" && values[0] != "default" {
+			count, err = strconv.Atoi(values[0])
+			if err != nil || count < 0 {
+				return errors.New(translate(m, "embed.bad_tags"))
+			}
+		}
+
+		edit = func(t *database.EmbedTemplate) error {
+			t.TagCount = count
+			return nil
+		}
+	case "footer":
+		edit = func(t *database.EmbedTemplate) error {
+			t.Footer, t.NoFooter = "", false
+			switch values[0] {
+			case "default":
+			case "none", "off":
+				t.NoFooter = true
+			default:
+				t.Footer = rawArgs(m, len(values))
+				if len(t.Footer) > 2048 {
+					return errors.New(translate(m, "embed.footer_too_long"))
+				}
+			}
+			return nil
 		}
 	default:
-		return errors.New("incorrect command usage. Please use bt!help set command for more information")
+		return errors.New(translate(m, "embed.unknown_property", property))
+	}
+
+	if err := database.DB.EditEmbedTemplate(m.GuildID, m.Author.ID, kind, edit); err != nil {
+		return err
+	}
+
+	template := database.GuildCache[m.GuildID].Template(kind)
+	s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+		Title:     translate(m, "embed.changed"),
+		Color:     template.ColorOr(utils.EmbedColor),
+		Fields:    []*discordgo.MessageEmbedField{{Name: translate(m, "embed.embed"), Value: kind, Inline: true}, {Name: translate(m, "embed.template"), Value: template.Describe(database.Locales(m.GuildID, m.Author.ID)), Inline: true}},
+		Timestamp: utils.EmbedTimestamp(),
+	})
+	return nil
+}
+
+//parseColor parses a hex colour, e.g. #439ef1. Default colour is zero.
+func parseColor(str string) (int, error) {
+	if str == "default" {
+		return 0, nil
+	}
+
+	str = strings.TrimPrefix(strings.TrimPrefix(str, "#"), "0x")
+	color, err := strconv.ParseInt(str, 16, 32)
+	if err != nil || color < 0 || color > 0xffffff {
+		return 0, fmt.Errorf("``%v`` is not a valid hex colour, e.g. #439ef1", str)
+	}
+
+	//zero means default colour, pure black is indistinguishable from #000001 anyway
+	if color == 0 {
+		color = 1
+	}
+
+	return int(color), nil
+}
+
+//rawArgs returns last n arguments of a message with their original case.
+func rawArgs(m *discordgo.MessageCreate, n int) string {
+	fields := strings.Fields(m.Content)
+	if n > len(fields) {
+		n = len(fields)
+	}
+
+	return strings.Join(fields[len(fields)-n:], " ")
+}
+
+//channelSettings are settings that can be overridden per channel. Nil value removes an override.
+var channelSettings = map[string]func(*database.ChannelOverride, interface{}){
+	"pixiv":         func(o *database.ChannelOverride, v interface{}) { o.Pixiv = boolOverride(v) },
+	"twitter":       func(o *database.ChannelOverride, v interface{}) { o.Twitter = boolOverride(v) },
+	"twitterprompt": func(o *database.ChannelOverride, v interface{}) { o.TwitterPrompt = boolOverride(v) },
+	"nsfw":          func(o *database.ChannelOverride, v interface{}) { o.NSFW = boolOverride(v) },
+	"limit": func(o *database.ChannelOverride, v interface{}) {
+		o.Limit = nil
+		if v != nil {
+			limit := v.(int)
+			o.Limit = &limit
+		}
+	},
+	"repost": func(o *database.ChannelOverride, v interface{}) {
+		o.Repost = nil
+		if v != nil {
+			repost := v.(string)
+			o.Repost = &repost
+		}
+	},
+}
+
+func boolOverride(v interface{}) *bool {
+	if v == nil {
+		return nil
+	}
+
+	b := v.(bool)
+	return &b
+}
+
+//setChannel shows or changes overrides of a channel.
+func setChannel(s *discordgo.Session, m *discordgo.MessageCreate, args []string) error {
+	if len(args) != 1 && len(args) != 3 {
+		return errors.New(translate(m, "settings.bad_usage"))
+	}
+
+	channelID := strings.Trim(args[0], "<#>")
+	ch, err := s.State.Channel(channelID)
+	if err != nil || ch.GuildID != m.GuildID {
+		return errors.New(translate(m, "settings.bad_channel", channelID))
+	}
+
+	if len(args) == 1 {
+		showChannelSettings(s, m, ch)
+		return nil
+	}
+
+	isManager, err := isBotManager(s, m)
+	if err != nil {
+		return err
+	}
+	if !isManager {
+		return utils.ErrNoPermission
+	}
+
+	setting := args[1]
+	if setting == "prompt" {
+		setting = "twitterprompt"
+	}
+
+	apply, ok := channelSettings[setting]
+	if !ok {
+		return errors.New(translate(m, "settings.not_per_channel", setting))
+	}
+
+	var (
+		newSetting = strings.ToLower(args[2])
+		value      interface{}
+	)
+
+	if newSetting != "inherit" && newSetting != "reset" {
+		value, err = settingMap[setting](s, m, newSetting)
+		if err != nil {
+			return err
+		}
+	} else {
+		newSetting = translate(m, "settings.inherited")
+	}
+
+	err = database.DB.EditChannelOverride(m.GuildID, m.Author.ID, ch.ID, func(o *database.ChannelOverride) error {
+		apply(o, value)
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
+	s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+		Title: translate(m, "settings.channel_changed"),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: translate(m, "settings.channel"), Value: fmt.Sprintf("<#%v>", ch.ID), Inline: true},
+			{Name: translate(m, "settings.setting"), Value: setting, Inline: true},
+			{Name: translate(m, "settings.new_value"), Value: newSetting, Inline: true},
+		},
+		Color:     utils.EmbedColor,
+		Timestamp: utils.EmbedTimestamp(),
+	})
 	return nil
 }
 
+func showChannelSettings(s *discordgo.Session, m *discordgo.MessageCreate, ch *discordgo.Channel) {
+	var (
+		settings = database.Settings(m.GuildID, ch.ID)
+		override = &database.ChannelOverride{}
+	)
+
+	if o, ok := database.GuildCache[m.GuildID].ChannelOverrides[ch.ID]; ok && o != nil {
+		override = o
+	}
+
+	s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
+		Title:       translate(m, "settings.channel_title"),
+		Description: fmt.Sprintf("<#%v>", ch.ID),
+		Color:       utils.EmbedColor,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  translate(m, "settings.overrides"),
+				Value: override.Describe(database.Locales(m.GuildID, m.Author.ID)),
+			},
+			{
+				Name:  translate(m, "settings.effective"),
+				Value: translate(m, "settings.effective_value", formatBool(m, settings.Pixiv), formatBool(m, settings.Twitter), formatBool(m, settings.TwitterPrompt), settings.Limit, settings.Repost, formatBool(m, settings.NSFW)),
+			},
+		},
+		Timestamp: utils.EmbedTimestamp(),
+	})
+}
+
 func showGuildSettings(s *discordgo.Session, m *discordgo.MessageCreate, settings *database.GuildSettings) {
 	guild, _ := s.Guild(settings.ID)
 
 	s.ChannelMessageSendEmbed(m.ChannelID, &discordgo.MessageEmbed{
-		Title:       "Current settings",
+		Title:       translate(m, "settings.title"),
 		Description: guild.Name,
 		Color:       utils.EmbedColor,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:  "General",
-				Value: fmt.Sprintf("**Prefix:** %v | **NSFW:** %v", settings.Prefix, utils.FormatBool(settings.NSFW)),
+				Name:  translate(m, "settings.general"),
+				Value: translate(m, "settings.general_value", settings.Prefix, formatBool(m, settings.NSFW)),
+			},
+			{
+				Name:  translate(m, "managers.title"),
+				Value: managerRoles(m, settings),
+			},
+			{
+				Name:  translate(m, "settings.features"),
+				Value: translate(m, "settings.features_value", settings.Repost, formatBool(m, settings.Crosspost), settings.SearchEngine(), formatEmoji(settings.ConfirmEmoji()), settings.FooterMode(), settings.Locale),
 			},
 			{
-				Name:  "Features",
-				Value: fmt.Sprintf("**Repost:** %v | **Crosspost**: %v", settings.Repost, utils.FormatBool(settings.Crosspost)),
+				Name:  translate(m, "settings.pixiv"),
+				Value: translate(m, "settings.pixiv_value", formatBool(m, settings.Pixiv), settings.Limit, formatLargeSet(m, settings.LargeSet), settings.UgoiraFormat),
 			},
 			{
-				Name:  "Pixiv settings",
-				Value: fmt.Sprintf("**Auto-repost (pixiv)**: %v | **Limit**: %v", utils.FormatBool(settings.Pixiv), settings.Limit),
+				Name:  translate(m, "settings.twitter"),
+				Value: translate(m, "settings.twitter_value", formatBool(m, settings.Twitter), formatBool(m, settings.TwitterPrompt)),
 			},
 			{
-				Name:  "Twitter settings",
-				Value: fmt.Sprintf("**Auto-repost (twitter)**: %v | **Prompt**: %v", utils.FormatBool(settings.Twitter), utils.FormatBool(settings.TwitterPrompt)),
+				Name:  translate(m, "settings.channel_overrides"),
+				Value: channelOverrides(m, settings),
+			},
+			{
+				Name:  translate(m, "embed.title"),
+				Value: embedTemplates(m, settings),
 			},
 		},
 		Thumbnail: &discordgo.MessageEmbedThumbnail{
-			URL: guild.IconURL(),
+			URL: guild.IconURL(""),
 		},
 		Timestamp: utils.EmbedTimestamp(),
 	})
 }
 
+func channelOverrides(m *discordgo.MessageCreate, settings *database.GuildSettings) string {
+	if len(settings.ChannelOverrides) == 0 {
+		return translate(m, "settings.no_channel_overrides")
+	}
+
+	locales := database.Locales(m.GuildID, m.Author.ID)
+
+	lines := make([]string, 0, len(settings.ChannelOverrides))
+	for id, o := range settings.ChannelOverrides {
+		lines = append(lines, fmt.Sprintf("<#%v>: %v", id, o.Describe(locales)))
+	}
+	sort.Strings(lines)
+
+	return truncateField(strings.Join(lines, "\n"))
+}
+
 func setBool(s *discordgo.Session, m *discordgo.MessageCreate, str string) (interface{}, error) {
 	return utils.ParseBool(str)
 }
@@ -130,7 +836,7 @@ func setPrefix(s *discordgo.Session, m *discordgo.MessageCreate, str string) (in
 		str += " "
 	}
 	if len(str) > 5 {
-		return nil, fmt.Errorf("new prefix (%v) is too long (%v). Maximum length is %v", s, len(str), 5)
+		return nil, errors.New(translate(m, "settings.long_prefix", str, len(str), 5))
 	}
 	return str, nil
 }
@@ -143,20 +849,124 @@ func setInt(s *discordgo.Session, m *discordgo.MessageCreate, str string) (inter
 	return ls, nil
 }
 
+func setLargeSet(s *discordgo.Session, m *discordgo.MessageCreate, str string) (interface{}, error) {
+	if str == "off" || str == "disabled" {
+		return 0, nil
+	}
+
+	ls, err := strconv.Atoi(str)
+	if err != nil {
+		return nil, utils.ErrParsingArgument
+	}
+	if ls < 0 {
+		return nil, errors.New(translate(m, "settings.bad_largeset"))
+	}
+	return ls, nil
+}
+
+func setReverseSearch(s *discordgo.Session, m *discordgo.MessageCreate, str string) (interface{}, error) {
+	if str != "saucenao" && str != "wait" {
+		return nil, errors.New(translate(m, "settings.bad_reverse_search"))
+	}
+	return str, nil
+}
+
+func setFooterMode(s *discordgo.Session, m *discordgo.MessageCreate, str string) (interface{}, error) {
+	switch str {
+	case database.FootersDefault, database.FootersCustom, database.FootersMixed, database.FootersOff:
+		return str, nil
+	case "disabled":
+		return database.FootersOff, nil
+	}
+	return nil, errors.New(translate(m, "settings.bad_footer"))
+}
+
+//setPromptEmoji accepts unicode emojis and emojis of the current server.
+func setPromptEmoji(s *discordgo.Session, m *discordgo.MessageCreate, str string) (interface{}, error) {
+	if match := customEmojiRegex.FindStringSubmatch(str); match != nil {
+		emojis, err := s.GuildEmojis(m.GuildID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range emojis {
+			if e.ID == match[2] {
+				return e.APIName(), nil
+			}
+		}
+
+		return nil, errors.New(translate(m, "settings.foreign_emoji"))
+	}
+
+	//plain text is rejected without a request, keycaps are the only emojis with an ASCII base
+	if !isKeycap(str) {
+		for _, r := range str {
+			if r < unicode.MaxASCII {
+				return nil, errors.New(translate(m, "settings.not_emoji", str))
+			}
+		}
+	}
+
+	//Discord is the only reliable judge of unicode emojis, a test reaction is rejected if it isn't one
+	if err := s.MessageReactionAdd(m.ChannelID, m.ID, str); err != nil {
+		if restErr, ok := err.(*discordgo.RESTError); ok && restErr.Message != nil && restErr.Message.Code == discordgo.ErrCodeUnknownEmoji {
+			return nil, errors.New(translate(m, "settings.not_emoji", str))
+		}
+		return nil, err
+	}
+
+	if err := s.MessageReactionRemove(m.ChannelID, m.ID, str, "@me"); err != nil {
+		log.Warnf("setPromptEmoji(): %v", err)
+	}
+
+	return str, nil
+}
+
+//keycapSuffix follows an ASCII base of keycap emojis, e.g. 1️⃣ and #️⃣.
+const keycapSuffix = "\ufe0f\u20e3"
+
+//isKeycap reports whether a string is a keycap emoji: a digit, # or * followed by a variation selector and a combining keycap.
+func isKeycap(str string) bool {
+	return len(str) == 1+len(keycapSuffix) && strings.ContainsAny(str[:1], "0123456789#*") && strings.HasSuffix(str, keycapSuffix)
+}
+
+//formatEmoji formats an emoji API name for a message.
+func formatEmoji(emoji string) string {
+	if strings.Contains(emoji, ":") {
+		return "<:" + emoji + ">"
+	}
+	return emoji
+}
+
+func formatLargeSet(m *discordgo.MessageCreate, size int) string {
+	if size == 0 {
+		return translate(m, "settings.disabled")
+	}
+	return strconv.Itoa(size)
+}
+
+func setUgoira(s *discordgo.Session, m *discordgo.MessageCreate, str string) (interface{}, error) {
+	format, err := ugoira.ParseFormat(str)
+	if err != nil {
+		return nil, err
+	}
+	return string(format), nil
+}
+
 func setRepost(s *discordgo.Session, m *discordgo.MessageCreate, str string) (interface{}, error) {
 	if str != "disabled" && str != "enabled" && str != "strict" {
-		return nil, errors.New("unknown option. repost only accepts enabled, disabled, and strict options")
+		return nil, errors.New(translate(m, "settings.bad_repost"))
 	}
 
 	if str == "enabled" || str == "strict" {
-		description := "Repost checking requires collecting following data. Do you agree sharing this information?"
+		description := translate(m, "consent.description")
 		if str == "strict" {
-			description += "\nPlease enable Manage Messages permission to remove reposts with strict mode on, otherwise strict mode is useless."
+			description += "\n" + translate(m, "repost.strict_permission")
 		}
 
-		agree := utils.CreatePromptWithMessage(s, m, &discordgo.MessageSend{
+		agree := prompt.CreateWithMessage(s, m, &discordgo.MessageSend{
 			Embed: &discordgo.MessageEmbed{
-				Title:     "Warning!",
+				Title:     translate(m, "consent.title"),
 				Color:     utils.EmbedColor,
 				Timestamp: utils.EmbedTimestamp(),
 				Thumbnail: &discordgo.MessageEmbedThumbnail{
@@ -165,28 +975,28 @@ func setRepost(s *discordgo.Session, m *discordgo.MessageCreate, str string) (in
 				Description: description,
 				Fields: []*discordgo.MessageEmbedField{
 					{
-						Name:  "Post content",
-						Value: "Pixiv ID or Twitter link. Essential for repost checking for obvious reasons",
+						Name:  translate(m, "consent.content"),
+						Value: translate(m, "consent.content_value"),
 					},
 					{
-						Name:  "Date and time of posting",
-						Value: "Required to remove repost from a database in 24 hours",
+						Name:  translate(m, "consent.date"),
+						Value: translate(m, "consent.date_value"),
 					},
 					{
-						Name:  "Poster's username (without an ID or discriminator)",
-						Value: "Required to give more information about the original poster when repost is detected",
+						Name:  translate(m, "consent.username"),
+						Value: translate(m, "consent.username_value"),
 					},
 					{
-						Name:  "Guild ID, message ID, and channel ID",
-						Value: "Essential for repost checking. Required to find a repost in a database and create a link to the original post.",
+						Name:  translate(m, "consent.ids"),
+						Value: translate(m, "consent.ids_value"),
 					},
 				},
 			},
-		})
+		}, database.GuildCache[m.GuildID].ConfirmEmoji())
 		if agree {
 			return str, nil
 		}
-		return nil, errors.New("cancelled enabling repost checker, ignore this error")
+		return nil, errors.New(translate(m, "consent.cancelled"))
 	}
 	return str, nil
 }
@@ -0,0 +1,521 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VTGare/boe-tea-go/internal/database"
+	"github.com/VTGare/boe-tea-go/internal/locale"
+	"github.com/VTGare/gumi"
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+//slashCommand is an application command backed by a gumi command, both interfaces share one implementation.
+type slashCommand struct {
+	name string
+	//command is a name of gumi command executed by the slash command. Description is taken from it.
+	command string
+	//args are prepended to arguments built from options, e.g. a sub-action of bt!server.
+	args []string
+	//flags maps option names to flags passed before their values, e.g. bt!set --channel.
+	flags       map[string]string
+	options     []*discordgo.ApplicationCommandOption
+	subcommands []*slashCommand
+	description string
+}
+
+var (
+	slashCooldowns   = make(map[string]time.Time)
+	slashCooldownsMu sync.Mutex
+)
+
+func stringOption(name, description string, required bool) *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{Type: discordgo.ApplicationCommandOptionString, Name: name, Description: description, Required: required}
+}
+
+func groupOption(required bool) *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{Type: discordgo.ApplicationCommandOptionString, Name: "group", Description: "Cross-post group name", Required: required, Autocomplete: true}
+}
+
+func channelOption(name, description string, required bool) *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:         discordgo.ApplicationCommandOptionChannel,
+		Name:         name,
+		Description:  description,
+		Required:     required,
+		ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+	}
+}
+
+func imageOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{Type: discordgo.ApplicationCommandOptionAttachment, Name: "image", Description: "Image attachment, prioritized over a link"}
+}
+
+func choiceOption(name, description string, required bool, choices ...string) *discordgo.ApplicationCommandOption {
+	opt := stringOption(name, description, required)
+	for _, c := range choices {
+		opt.Choices = append(opt.Choices, &discordgo.ApplicationCommandOptionChoice{Name: c, Value: c})
+	}
+
+	return opt
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+//slashCommands describes application commands. It's built lazily because setting maps are filled in init functions.
+func slashCommands() []*slashCommand {
+	settings := make(map[string]bool)
+	for k := range settingMap {
+		settings[k] = true
+	}
+	//embed templates and footer messages take their arguments from the value option
+	settings["embed"] = true
+	settings["footers"] = true
+
+	groupSettings := make(map[string]bool)
+	for k := range groupSettingMap {
+		groupSettings[k] = true
+	}
+
+	var (
+		channels     = stringOption("channels", "Channel IDs or mentions", false)
+		rules        = choiceOption("rule", "Filter rule", true, "tags", "exclude", "rating", "provider", "likes", "clear")
+		ruleValues   = stringOption("values", "Rule values", false)
+		groupSetting = choiceOption("setting", "Group setting", true, sortedKeys(groupSettings)...)
+		settingValue = stringOption("value", "New value", true)
+		duration     = stringOption("duration", "Pause duration, e.g. 2h or 3d", true)
+		action       = choiceOption("action", "Add or remove source channels", true, "add", "remove")
+	)
+
+	groupCommands := func(server bool) []*slashCommand {
+		sub := func(name, command string, options ...*discordgo.ApplicationCommandOption) *slashCommand {
+			if server {
+				//server groups are managed by sub-actions of a single bt!server command
+				return &slashCommand{name: name, command: "server", args: []string{name}, options: options, description: "Server group command: " + name}
+			}
+
+			return &slashCommand{name: name, command: command, options: options}
+		}
+
+		cmds := []*slashCommand{
+			sub("list", "list"),
+			sub("create", "create", stringOption("name", "New group name", true), stringOption("channels", "Parent channel followed by children, IDs or mentions", false)),
+			sub("delete", "delete", groupOption(true)),
+			sub("push", "push", groupOption(true), channels),
+			sub("pop", "pop", groupOption(true), channels),
+			sub("filter", "filter", groupOption(true), channelOption("channel", "Filtered channel", true), rules, ruleValues),
+			sub("set", "groupset", groupOption(true), groupSetting, settingValue),
+			sub("source", "source", groupOption(true), action, channels),
+		}
+
+		if server {
+			return append(cmds,
+				sub("toggle", "toggle", groupOption(true)),
+				sub("pause", "pause", groupOption(true), duration),
+				sub("resume", "resume", groupOption(true)),
+			)
+		}
+
+		return append(cmds,
+			sub("copy", "copy", &discordgo.ApplicationCommandOption{Type: discordgo.ApplicationCommandOptionString, Name: "source", Description: "Source group name", Required: true, Autocomplete: true}, stringOption("destination", "New group name", true), channelOption("parent", "Parent channel of a new group", true)),
+			sub("export", "export"),
+			sub("import", "import", &discordgo.ApplicationCommandOption{Type: discordgo.ApplicationCommandOptionAttachment, Name: "file", Description: "Exported JSON file", Required: true}, choiceOption("mode", "Import mode", false, "merge", "replace")),
+			sub("toggle", "toggle", groupOption(false)),
+			sub("pause", "pause", duration, groupOption(false)),
+			sub("resume", "resume", groupOption(false)),
+		)
+	}
+
+	return []*slashCommand{
+		{name: "sauce", command: "sauce", options: []*discordgo.ApplicationCommandOption{choiceOption("engine", "Reverse search engine, server's default if omitted", false, "saucenao", "wait"), stringOption("url", "Image link", false), imageOption()}},
+		{name: "wait", command: "wait", options: []*discordgo.ApplicationCommandOption{stringOption("url", "Image link", false), imageOption()}},
+		{name: "exclude", command: "exclude", options: []*discordgo.ApplicationCommandOption{stringOption("url", "Post link", true), stringOption("images", "Excluded images, e.g. 1 3-5", false)}},
+		{name: "include", command: "include", options: []*discordgo.ApplicationCommandOption{stringOption("url", "Post link", true), stringOption("images", "Included images, e.g. 1 3-5", false)}},
+		{name: "crosspost", command: "crosspost", options: []*discordgo.ApplicationCommandOption{stringOption("url", "Twitter or Pixiv link", true), stringOption("exclude", "Excluded channels or all", false)}},
+		{name: "ugoira", command: "ugoira", options: []*discordgo.ApplicationCommandOption{stringOption("url", "Pixiv link", true), choiceOption("format", "Animation format", false, "mp4", "gif", "webm", "apng")}},
+		{name: "twitter", command: "twitter", options: []*discordgo.ApplicationCommandOption{stringOption("url", "Twitter link", true)}},
+		{name: "deepfry", command: "deepfry", options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionInteger, Name: "times", Description: "Times deepfried"},
+			stringOption("url", "Image link", false),
+			imageOption(),
+		}},
+		{name: "jpeg", command: "jpeg", options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionInteger, Name: "quality", Description: "Image quality from 0 to 100"},
+			stringOption("url", "Image link", false),
+			imageOption(),
+		}},
+		{name: "nhentai", command: "nhentai", options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionInteger, Name: "number", Description: "Magic number", Required: true},
+		}},
+		{name: "set", command: "set", flags: map[string]string{"channel": "--channel"}, options: []*discordgo.ApplicationCommandOption{
+			channelOption("channel", "Show or change overrides of a channel", false),
+			choiceOption("setting", "Setting to change, omit to show settings", false, sortedKeys(settings)...),
+			stringOption("value", "New setting", false),
+		}},
+		{name: "locale", command: "locale", options: []*discordgo.ApplicationCommandOption{choiceOption("language", "New language, omit to show your language", false, append(locale.Supported(), "reset")...)}},
+		{name: "group", description: "Manages your cross-post groups", subcommands: groupCommands(false)},
+		{name: "server", description: "Manages server-wide cross-post groups", subcommands: groupCommands(true)},
+	}
+}
+
+//findCommand looks up a gumi command by its name.
+func findCommand(name string) *gumi.Command {
+	for _, g := range Router.Groups {
+		if cmd, ok := g.Commands[name]; ok {
+			return cmd
+		}
+	}
+
+	return nil
+}
+
+func truncateDescription(s string) string {
+	s = strings.ReplaceAll(s, "``", "")
+	if runes := []rune(s); len(runes) > 100 {
+		return string(runes[:97]) + "..."
+	}
+
+	return s
+}
+
+func (sc *slashCommand) applicationOptions() []*discordgo.ApplicationCommandOption {
+	if len(sc.subcommands) == 0 {
+		return sc.options
+	}
+
+	options := make([]*discordgo.ApplicationCommandOption, 0, len(sc.subcommands))
+	for _, sub := range sc.subcommands {
+		options = append(options, &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        sub.name,
+			Description: sub.describe(),
+			Options:     sub.options,
+		})
+	}
+
+	return options
+}
+
+func (sc *slashCommand) describe() string {
+	if sc.description != "" {
+		return truncateDescription(sc.description)
+	}
+
+	if cmd := findCommand(sc.command); cmd != nil && cmd.Description != "" {
+		return truncateDescription(cmd.Description)
+	}
+
+	return sc.name
+}
+
+//RegisterSlashCommands overwrites global application commands with slash versions of gumi commands.
+func RegisterSlashCommands(s *discordgo.Session) error {
+	cmds := make([]*discordgo.ApplicationCommand, 0)
+	for _, sc := range slashCommands() {
+		cmds = append(cmds, &discordgo.ApplicationCommand{
+			Name:        sc.name,
+			Description: sc.describe(),
+			Options:     sc.applicationOptions(),
+		})
+	}
+
+	_, err := s.ApplicationCommandBulkOverwrite(s.State.User.ID, "", cmds)
+	return err
+}
+
+//resolveSlashCommand finds a slash command spec and options of an invoked (sub)command.
+func resolveSlashCommand(data discordgo.ApplicationCommandInteractionData) (*slashCommand, []*discordgo.ApplicationCommandInteractionDataOption) {
+	for _, sc := range slashCommands() {
+		if sc.name != data.Name {
+			continue
+		}
+
+		if len(sc.subcommands) == 0 {
+			return sc, data.Options
+		}
+
+		if len(data.Options) == 0 {
+			return nil, nil
+		}
+
+		for _, sub := range sc.subcommands {
+			if sub.name == data.Options[0].Name {
+				return sub, data.Options[0].Options
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//slashArgs converts interaction options to positional arguments in the declared order, the same way gumi splits message content.
+//Raw arguments keep the original case of string options, they make up content of a message event.
+//Attachment options are returned separately.
+func (sc *slashCommand) slashArgs(options []*discordgo.ApplicationCommandInteractionDataOption, resolved *discordgo.ApplicationCommandInteractionDataResolved) ([]string, []string, []*discordgo.MessageAttachment) {
+	var (
+		args        = append([]string{}, sc.args...)
+		raw         = append([]string{}, sc.args...)
+		attachments = make([]*discordgo.MessageAttachment, 0)
+		values      = make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	)
+
+	for _, opt := range options {
+		values[opt.Name] = opt
+	}
+
+	for _, decl := range sc.options {
+		opt, ok := values[decl.Name]
+		if !ok {
+			continue
+		}
+
+		if flag, ok := sc.flags[decl.Name]; ok {
+			args = append(args, flag)
+			raw = append(raw, flag)
+		}
+
+		switch opt.Type {
+		case discordgo.ApplicationCommandOptionString:
+			args = append(args, strings.Fields(strings.ToLower(opt.StringValue()))...)
+			raw = append(raw, strings.Fields(opt.StringValue())...)
+		case discordgo.ApplicationCommandOptionInteger:
+			value := strconv.FormatInt(opt.IntValue(), 10)
+			args, raw = append(args, value), append(raw, value)
+		case discordgo.ApplicationCommandOptionBoolean:
+			value := strconv.FormatBool(opt.BoolValue())
+			args, raw = append(args, value), append(raw, value)
+		case discordgo.ApplicationCommandOptionChannel:
+			value := fmt.Sprintf("<#%v>", opt.Value)
+			args, raw = append(args, value), append(raw, value)
+		case discordgo.ApplicationCommandOptionAttachment:
+			if resolved == nil {
+				continue
+			}
+
+			if id, ok := opt.Value.(string); ok {
+				if att, ok := resolved.Attachments[id]; ok {
+					attachments = append(attachments, att)
+				}
+			}
+		}
+	}
+
+	return args, raw, attachments
+}
+
+//interactionMessage builds a message event out of an interaction so gumi handlers can execute it.
+//Its ID is set to the ID of a deferred response once it's sent.
+func interactionMessage(i *discordgo.InteractionCreate, name string, raw []string, attachments []*discordgo.MessageAttachment) *discordgo.MessageCreate {
+	author := i.User
+	if i.Member != nil {
+		author = i.Member.User
+	}
+
+	return &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			ChannelID:   i.ChannelID,
+			GuildID:     i.GuildID,
+			Author:      author,
+			Member:      i.Member,
+			Content:     strings.TrimSpace("/" + name + " " + strings.Join(raw, " ")),
+			Attachments: attachments,
+			Timestamp:   time.Now(),
+		},
+	}
+}
+
+func slashOnCooldown(cmd *gumi.Command, userID string) time.Duration {
+	if cmd.Cooldown == 0 {
+		return 0
+	}
+
+	slashCooldownsMu.Lock()
+	defer slashCooldownsMu.Unlock()
+
+	key := cmd.Name + userID
+	if last, ok := slashCooldowns[key]; ok {
+		if left := cmd.Cooldown - time.Since(last); left > 0 {
+			return left
+		}
+	}
+
+	slashCooldowns[key] = time.Now()
+	return 0
+}
+
+//respondEphemeral responds to an interaction with a message only its user can see, in the language of the user.
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, key string, args ...interface{}) {
+	user := i.User
+	if i.Member != nil {
+		user = i.Member.User
+	}
+
+	var locales []string
+	if user != nil {
+		locales = database.Locales(i.GuildID, user.ID)
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Content: locale.Get(locales, key, args...), Flags: discordgo.MessageFlagsEphemeral},
+	})
+	if err != nil {
+		logrus.Warnf("InteractionRespond(): %v", err)
+	}
+}
+
+//HandleInteraction executes slash commands and answers group name autocompletion.
+func HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		executeSlashCommand(s, i)
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		autocompleteGroups(s, i)
+	}
+}
+
+func executeSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	sc, options := resolveSlashCommand(data)
+	if sc == nil {
+		respondEphemeral(s, i, "slash.unknown")
+		return
+	}
+
+	cmd := findCommand(sc.command)
+	if cmd == nil {
+		respondEphemeral(s, i, "slash.unknown")
+		return
+	}
+
+	if cmd.GuildOnly && i.GuildID == "" {
+		respondEphemeral(s, i, "slash.guild_only", cmd.Name)
+		return
+	}
+
+	if cmd.NSFW {
+		channel, err := s.Channel(i.ChannelID)
+		if err != nil || !channel.NSFW {
+			respondEphemeral(s, i, "slash.nsfw")
+			return
+		}
+	}
+
+	args, raw, attachments := sc.slashArgs(options, data.Resolved)
+	m := interactionMessage(i, data.Name, raw, attachments)
+	if cd := slashOnCooldown(cmd, m.Author.ID); cd != 0 {
+		respondEphemeral(s, i, "slash.cooldown", cd.Round(1*time.Second).String(), cmd.Name)
+		return
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+	if err != nil {
+		logrus.Warnf("InteractionRespond(): %v", err)
+		return
+	}
+
+	//deferred response stands in for an invoking message, handlers reply to it, check reposts by it and delete it
+	response, err := s.InteractionResponse(i.Interaction)
+	if err != nil {
+		logrus.Warnf("InteractionResponse(): %v", err)
+		return
+	}
+	m.ID = response.ID
+
+	go func() {
+		logrus.Infof("Executing slash command: %s. Arguments: %v", cmd.Name, args)
+		var (
+			err     = cmd.Exec(s, m, args)
+			content = m.Content
+			edit    = &discordgo.WebhookEdit{Content: &content, AllowedMentions: &discordgo.MessageAllowedMentions{}}
+		)
+
+		if errorMessage := Router.ErrorHandler(err); errorMessage != nil {
+			embeds := []*discordgo.MessageEmbed{errorMessage.Embed}
+			edit.Embeds = &embeds
+		}
+
+		if _, err := s.InteractionResponseEdit(i.Interaction, edit); err != nil {
+			logrus.Warnf("InteractionResponseEdit(): %v", err)
+		}
+	}()
+}
+
+//focusedOption returns a focused option of an autocomplete interaction.
+func focusedOption(options []*discordgo.ApplicationCommandInteractionDataOption) *discordgo.ApplicationCommandInteractionDataOption {
+	for _, opt := range options {
+		if opt.Focused {
+			return opt
+		}
+
+		if found := focusedOption(opt.Options); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+func autocompleteGroups(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var (
+		data    = i.ApplicationCommandData()
+		focused = focusedOption(data.Options)
+		groups  = make([]*database.Group, 0)
+		choices = make([]*discordgo.ApplicationCommandOptionChoice, 0)
+	)
+
+	if data.Name == "server" {
+		if guild, ok := database.GuildCache[i.GuildID]; ok {
+			groups = guild.ChannelGroups
+		}
+	} else {
+		userID := ""
+		if i.Member != nil {
+			userID = i.Member.User.ID
+		} else if i.User != nil {
+			userID = i.User.ID
+		}
+
+		if user := database.DB.FindUser(userID); user != nil {
+			groups = user.ChannelGroups
+		}
+	}
+
+	prefix := ""
+	if focused != nil {
+		prefix = strings.ToLower(focused.StringValue())
+	}
+
+	for _, g := range groups {
+		if len(choices) == 25 {
+			break
+		}
+
+		if strings.HasPrefix(strings.ToLower(g.Name), prefix) {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: g.Name, Value: g.Name})
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
+	})
+	if err != nil {
+		logrus.Warnf("InteractionRespond(): %v", err)
+	}
+}
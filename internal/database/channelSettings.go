@@ -0,0 +1,125 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/VTGare/boe-tea-go/internal/locale"
+)
+
+//ChannelOverride overrides guild settings in a single channel. Nil fields inherit guild's values.
+type ChannelOverride struct {
+	Pixiv         *bool   `bson:"pixiv,omitempty" json:"pixiv,omitempty"`
+	Twitter       *bool   `bson:"twitter,omitempty" json:"twitter,omitempty"`
+	TwitterPrompt *bool   `bson:"twitterprompt,omitempty" json:"twitterprompt,omitempty"`
+	Limit         *int    `bson:"limit,omitempty" json:"limit,omitempty"`
+	Repost        *string `bson:"repost,omitempty" json:"repost,omitempty"`
+	NSFW          *bool   `bson:"nsfw,omitempty" json:"nsfw,omitempty"`
+}
+
+//Settings returns effective settings of a channel: guild settings with channel's overrides applied.
+//Guild settings are returned as is if a channel has no overrides, nil is returned for unknown guilds.
+func Settings(guildID, channelID string) *GuildSettings {
+	guild, ok := GuildCache[guildID]
+	if !ok {
+		return nil
+	}
+
+	override, ok := guild.ChannelOverrides[channelID]
+	if !ok || override == nil {
+		return guild
+	}
+
+	effective := *guild
+	if override.Pixiv != nil {
+		effective.Pixiv = *override.Pixiv
+	}
+	if override.Twitter != nil {
+		effective.Twitter = *override.Twitter
+	}
+	if override.TwitterPrompt != nil {
+		effective.TwitterPrompt = *override.TwitterPrompt
+	}
+	if override.Limit != nil {
+		effective.Limit = *override.Limit
+	}
+	if override.Repost != nil {
+		effective.Repost = *override.Repost
+	}
+	if override.NSFW != nil {
+		effective.NSFW = *override.NSFW
+	}
+
+	return &effective
+}
+
+//IsEmpty reports whether an override doesn't change anything.
+func (o *ChannelOverride) IsEmpty() bool {
+	return o.Pixiv == nil && o.Twitter == nil && o.TwitterPrompt == nil && o.Limit == nil && o.Repost == nil && o.NSFW == nil
+}
+
+//Describe returns human-readable list of overridden settings in the first available of given locales.
+func (o *ChannelOverride) Describe(locales []string) string {
+	fields := make([]string, 0)
+	add := func(name string, value interface{}) {
+		fields = append(fields, fmt.Sprintf("**%v**: %v", name, value))
+	}
+
+	if o.Pixiv != nil {
+		add("pixiv", *o.Pixiv)
+	}
+	if o.Twitter != nil {
+		add("twitter", *o.Twitter)
+	}
+	if o.TwitterPrompt != nil {
+		add("twitterprompt", *o.TwitterPrompt)
+	}
+	if o.Limit != nil {
+		add("limit", strconv.Itoa(*o.Limit))
+	}
+	if o.Repost != nil {
+		add("repost", *o.Repost)
+	}
+	if o.NSFW != nil {
+		add("nsfw", *o.NSFW)
+	}
+
+	if len(fields) == 0 {
+		return locale.Get(locales, "settings.no_overrides")
+	}
+
+	return strings.Join(fields, " | ")
+}
+
+//EditChannelOverride applies changes to channel's overrides and saves them. Empty overrides are removed.
+func (d *Database) EditChannelOverride(guildID, userID, channelID string, edit func(*ChannelOverride) error) error {
+	guild, ok := GuildCache[guildID]
+	if !ok {
+		return fmt.Errorf("Guild not found: %v", guildID)
+	}
+
+	//cache is replaced by ChangeSetting, so current overrides are left untouched if saving fails
+	overrides := make(map[string]*ChannelOverride, len(guild.ChannelOverrides)+1)
+	for id, o := range guild.ChannelOverrides {
+		overrides[id] = o
+	}
+
+	override := &ChannelOverride{}
+	if current, ok := overrides[channelID]; ok && current != nil {
+		copied := *current
+		override = &copied
+	}
+
+	if err := edit(override); err != nil {
+		return err
+	}
+
+	if override.IsEmpty() {
+		delete(overrides, channelID)
+	} else {
+		overrides[channelID] = override
+	}
+
+	return d.ChangeSetting(guildID, userID, "channel_overrides", overrides)
+}
@@ -0,0 +1,281 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/VTGare/boe-tea-go/internal/locale"
+)
+
+//Target is a cross-post destination channel with filters of every group it was resolved from.
+type Target struct {
+	ChannelID string
+	Filters   []*Filter
+	//Webhook is true if any of target's groups delivers posts through webhooks.
+	Webhook bool
+	//Attachments is true if any of target's groups re-uploads image attachments.
+	Attachments bool
+}
+
+//Filter restricts which posts are cross-posted to a child channel of a group. Empty fields match everything.
+//Twitter posts have no tags and are considered SFW.
+type Filter struct {
+	IncludeTags []string `json:"include_tags,omitempty" bson:"include_tags,omitempty"`
+	ExcludeTags []string `json:"exclude_tags,omitempty" bson:"exclude_tags,omitempty"`
+	Rating      string   `json:"rating,omitempty" bson:"rating,omitempty"`
+	Providers   []string `json:"providers,omitempty" bson:"providers,omitempty"`
+	MinLikes    int      `json:"min_likes,omitempty" bson:"min_likes,omitempty"`
+}
+
+//Allows reports whether a post passes the filter. Include tags require at least one of them to be present.
+func (f *Filter) Allows(provider string, tags []string, nsfw bool, likes int) bool {
+	if f == nil {
+		return true
+	}
+
+	switch {
+	case f.Rating == "sfw" && nsfw:
+		return false
+	case f.Rating == "nsfw" && !nsfw:
+		return false
+	case likes < f.MinLikes:
+		return false
+	case len(f.Providers) > 0 && !containsFold(f.Providers, provider):
+		return false
+	}
+
+	for _, tag := range f.ExcludeTags {
+		if containsFold(tags, tag) {
+			return false
+		}
+	}
+
+	if len(f.IncludeTags) == 0 {
+		return true
+	}
+
+	for _, tag := range f.IncludeTags {
+		if containsFold(tags, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//IsEmpty reports whether the filter has no rules.
+func (f *Filter) IsEmpty() bool {
+	return f == nil || (len(f.IncludeTags) == 0 && len(f.ExcludeTags) == 0 && f.Rating == "" && len(f.Providers) == 0 && f.MinLikes == 0)
+}
+
+//Describe returns human-readable list of filter's rules in the first available of given locales.
+func (f *Filter) Describe(locales []string) string {
+	if f.IsEmpty() {
+		return "-"
+	}
+
+	rules := make([]string, 0)
+	if len(f.IncludeTags) > 0 {
+		rules = append(rules, locale.Get(locales, "filter.tags", strings.Join(f.IncludeTags, ", ")))
+	}
+	if len(f.ExcludeTags) > 0 {
+		rules = append(rules, locale.Get(locales, "filter.excluded", strings.Join(f.ExcludeTags, ", ")))
+	}
+	if f.Rating != "" {
+		rules = append(rules, locale.Get(locales, "filter.rating", f.Rating))
+	}
+	if len(f.Providers) > 0 {
+		rules = append(rules, locale.Get(locales, "filter.providers", strings.Join(f.Providers, ", ")))
+	}
+	if f.MinLikes > 0 {
+		rules = append(rules, locale.Get(locales, "filter.likes", f.MinLikes))
+	}
+
+	return strings.Join(rules, " | ")
+}
+
+//Allows reports whether a post can be sent to the target. Target allows a post if any of its groups does.
+func (t *Target) Allows(provider string, tags []string, nsfw bool, likes int) bool {
+	if len(t.Filters) == 0 {
+		return true
+	}
+
+	for _, f := range t.Filters {
+		if f.Allows(provider, tags, nsfw, likes) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//HasFilters reports whether any of target's filters has rules.
+func (t *Target) HasFilters() bool {
+	for _, f := range t.Filters {
+		if !f.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+//Filter returns a filter of a child channel, nil if there's none.
+func (g *Group) Filter(channelID string) *Filter {
+	if g.Filters == nil {
+		return nil
+	}
+	return g.Filters[channelID]
+}
+
+//SetFilter sets or removes (if the filter is empty) a filter of a child channel.
+func (g *Group) SetFilter(channelID string, f *Filter) {
+	if f.IsEmpty() {
+		delete(g.Filters, channelID)
+		return
+	}
+
+	if g.Filters == nil {
+		g.Filters = make(map[string]*Filter)
+	}
+	g.Filters[channelID] = f
+}
+
+//Copy returns a deep copy of a group, so it can be edited without touching the cached one.
+func (g *Group) Copy() *Group {
+	copied := *g
+	copied.Children = append(make([]string, 0, len(g.Children)), g.Children...)
+	copied.Parents = append([]string(nil), g.Parents...)
+
+	if g.Filters != nil {
+		copied.Filters = make(map[string]*Filter, len(g.Filters))
+		for id, f := range g.Filters {
+			copied.Filters[id] = f.Copy()
+		}
+	}
+
+	return &copied
+}
+
+//Copy returns a deep copy of a filter. It's safe to call on a nil filter.
+func (f *Filter) Copy() *Filter {
+	if f == nil {
+		return nil
+	}
+
+	copied := *f
+	copied.IncludeTags = append([]string(nil), f.IncludeTags...)
+	copied.ExcludeTags = append([]string(nil), f.ExcludeTags...)
+	copied.Providers = append([]string(nil), f.Providers...)
+	return &copied
+}
+
+//IsSource reports whether a channel is a parent of a group, not counting mesh mode.
+func (g *Group) IsSource(channelID string) bool {
+	if g.Parent == channelID {
+		return true
+	}
+
+	for _, p := range g.Parents {
+		if p == channelID {
+			return true
+		}
+	}
+
+	return false
+}
+
+//Members returns parents and children of a group.
+func (g *Group) Members() []string {
+	members := make([]string, 0, 1+len(g.Parents)+len(g.Children))
+	members = append(members, g.Parent)
+	members = append(members, g.Parents...)
+	return append(members, g.Children...)
+}
+
+//HasSource reports whether posts from a channel are cross-posted by a group.
+//Every member of a mesh group is a source.
+func (g *Group) HasSource(channelID string) bool {
+	if !g.Mesh {
+		return g.IsSource(channelID)
+	}
+
+	for _, c := range g.Members() {
+		if c == channelID {
+			return true
+		}
+	}
+
+	return false
+}
+
+//Destinations returns channels a post from a source channel is cross-posted to.
+//Mesh groups send posts to every other member, regular groups only to children.
+func (g *Group) Destinations(source string) []string {
+	channels := g.Children
+	if g.Mesh {
+		channels = g.Members()
+	}
+
+	dest := make([]string, 0, len(channels))
+	for _, c := range channels {
+		if c != source {
+			dest = append(dest, c)
+		}
+	}
+
+	return dest
+}
+
+//CrosspostTargets returns a deduplicated list of channels a post from a parent channel should be cross-posted to.
+//It combines user's personal groups with server groups of the guild the post was made in.
+//Disabled and paused groups are skipped, nothing is returned if user has turned cross-posting off.
+func CrosspostTargets(guildID, userID, channelID string) []*Target {
+	var (
+		targets = make([]*Target, 0)
+		seen    = make(map[string]*Target)
+	)
+
+	add := func(groups []*Group) {
+		for _, g := range groups {
+			if !g.HasSource(channelID) || !g.Active() {
+				continue
+			}
+
+			for _, id := range g.Destinations(channelID) {
+
+				t, ok := seen[id]
+				if !ok {
+					t = &Target{ChannelID: id}
+					seen[id] = t
+					targets = append(targets, t)
+				}
+
+				t.Filters = append(t.Filters, g.Filter(id))
+				t.Webhook = t.Webhook || g.Webhook
+				t.Attachments = t.Attachments || !g.NoAttachments
+			}
+		}
+	}
+
+	user := DB.FindUser(userID)
+	if user != nil {
+		if !user.CrosspostEnabled() {
+			return targets
+		}
+		add(user.ChannelGroups)
+	}
+
+	if guild, ok := GuildCache[guildID]; ok {
+		add(guild.ChannelGroups)
+	}
+
+	return targets
+}
+
+func containsFold(arr []string, str string) bool {
+	for _, s := range arr {
+		if strings.EqualFold(s, str) {
+			return true
+		}
+	}
+	return false
+}
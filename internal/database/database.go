@@ -38,6 +38,7 @@ type Database struct {
 	UserSettings  *mongo.Collection
 	posts         *mongo.Collection
 	stats         *mongo.Collection
+	history       *mongo.Collection
 }
 
 func (d *Database) Close() {
@@ -55,7 +56,7 @@ func NewDatabase(url, dbname string) (*Database, error) {
 
 	db := client.Database(dbname)
 
-	d := &Database{db, client, db.Collection("guildsettings"), db.Collection("user_settings"), db.Collection("image_posts"), db.Collection("stats")}
+	d := &Database{db, client, db.Collection("guildsettings"), db.Collection("user_settings"), db.Collection("image_posts"), db.Collection("stats"), db.Collection("settings_history")}
 	_, err = d.AllUsers()
 	_, err = d.AllGuilds()
 
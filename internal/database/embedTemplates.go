@@ -0,0 +1,176 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/VTGare/boe-tea-go/internal/locale"
+	"github.com/bwmarrin/discordgo"
+)
+
+//Embed kinds that can be customised with templates.
+const (
+	PixivEmbed   = "pixiv"
+	TwitterEmbed = "twitter"
+	RepostEmbed  = "repost"
+	SauceEmbed   = "sauce"
+)
+
+//EmbedFields lists fields of every embed kind that can be hidden by a template.
+var EmbedFields = map[string][]string{
+	PixivEmbed:   {"tags", "likes", "original"},
+	TwitterEmbed: {"text", "retweets", "likes"},
+	RepostEmbed:  {"content", "link", "expires"},
+	SauceEmbed:   {"source", "urls", "similarity", "author", "timestamp", "episode"},
+}
+
+//EmbedTemplate customises appearance of one kind of bot's embeds. Zero values keep bot's defaults.
+type EmbedTemplate struct {
+	Color        int      `bson:"color,omitempty" json:"color,omitempty"`
+	Thumbnail    string   `bson:"thumbnail,omitempty" json:"thumbnail,omitempty"`
+	NoThumbnail  bool     `bson:"no_thumbnail,omitempty" json:"no_thumbnail,omitempty"`
+	HiddenFields []string `bson:"hidden_fields,omitempty" json:"hidden_fields,omitempty"`
+	TagCount     int      `bson:"tag_count,omitempty" json:"tag_count,omitempty"`
+	Footer       string   `bson:"footer,omitempty" json:"footer,omitempty"`
+	NoFooter     bool     `bson:"no_footer,omitempty" json:"no_footer,omitempty"`
+}
+
+//Template returns guild's embed template of a given kind. Empty template is returned if a guild hasn't customised it.
+func (gs *GuildSettings) Template(kind string) *EmbedTemplate {
+	if gs != nil {
+		if t, ok := gs.EmbedTemplates[kind]; ok && t != nil {
+			return t
+		}
+	}
+
+	return &EmbedTemplate{}
+}
+
+//GuildTemplate returns embed template of a guild by its ID. Empty template is returned for unknown guilds and DMs.
+func GuildTemplate(guildID, kind string) *EmbedTemplate {
+	return GuildCache[guildID].Template(kind)
+}
+
+//Shows reports whether a field is visible.
+func (t *EmbedTemplate) Shows(field string) bool {
+	for _, hidden := range t.HiddenFields {
+		if hidden == field {
+			return false
+		}
+	}
+
+	return true
+}
+
+//ColorOr returns template's colour or a default one.
+func (t *EmbedTemplate) ColorOr(def int) int {
+	if t.Color == 0 {
+		return def
+	}
+
+	return t.Color
+}
+
+//Apply applies template's colour, thumbnail and footer to an embed.
+//Hidden fields aren't removed, embeds skip them with Shows when they're created.
+func (t *EmbedTemplate) Apply(embed *discordgo.MessageEmbed) *discordgo.MessageEmbed {
+	embed.Color = t.ColorOr(embed.Color)
+
+	switch {
+	case t.NoThumbnail:
+		embed.Thumbnail = nil
+	case t.Thumbnail != "":
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: t.Thumbnail}
+	}
+
+	switch {
+	case t.NoFooter:
+		embed.Footer = nil
+	case t.Footer != "":
+		if embed.Footer == nil {
+			embed.Footer = &discordgo.MessageEmbedFooter{}
+		}
+		embed.Footer.Text = t.Footer
+	}
+
+	return embed
+}
+
+//Tags trims tags to template's tag count.
+func (t *EmbedTemplate) Tags(tags []string) []string {
+	if t.TagCount > 0 && len(tags) > t.TagCount {
+		return tags[:t.TagCount]
+	}
+
+	return tags
+}
+
+//IsEmpty reports whether a template doesn't change anything.
+func (t *EmbedTemplate) IsEmpty() bool {
+	return t.Color == 0 && t.Thumbnail == "" && !t.NoThumbnail && len(t.HiddenFields) == 0 && t.TagCount == 0 && t.Footer == "" && !t.NoFooter
+}
+
+//Describe returns human-readable description of a template in the first available of given locales.
+func (t *EmbedTemplate) Describe(locales []string) string {
+	if t.IsEmpty() {
+		return locale.Get(locales, "embed.default")
+	}
+
+	fields := make([]string, 0)
+	if t.Color != 0 {
+		fields = append(fields, fmt.Sprintf("**color**: #%06x", t.Color))
+	}
+	if t.NoThumbnail {
+		fields = append(fields, "**thumbnail**: none")
+	} else if t.Thumbnail != "" {
+		fields = append(fields, fmt.Sprintf("**thumbnail**: %v", t.Thumbnail))
+	}
+	if len(t.HiddenFields) != 0 {
+		fields = append(fields, fmt.Sprintf("**hidden**: %v", strings.Join(t.HiddenFields, ", ")))
+	}
+	if t.TagCount != 0 {
+		fields = append(fields, fmt.Sprintf("**tags**: %v", t.TagCount))
+	}
+	if t.NoFooter {
+		fields = append(fields, "**footer**: none")
+	} else if t.Footer != "" {
+		fields = append(fields, fmt.Sprintf("**footer**: %v", t.Footer))
+	}
+
+	return strings.Join(fields, " | ")
+}
+
+//EditEmbedTemplate applies changes to guild's embed template and saves it. Templates equal to defaults are removed.
+func (d *Database) EditEmbedTemplate(guildID, userID, kind string, edit func(*EmbedTemplate) error) error {
+	guild, ok := GuildCache[guildID]
+	if !ok {
+		return fmt.Errorf("Guild not found: %v", guildID)
+	}
+
+	if _, ok := EmbedFields[kind]; !ok {
+		return fmt.Errorf("unknown embed kind: %v", kind)
+	}
+
+	templates := make(map[string]*EmbedTemplate, len(guild.EmbedTemplates)+1)
+	for k, t := range guild.EmbedTemplates {
+		templates[k] = t
+	}
+
+	template := &EmbedTemplate{}
+	if current, ok := templates[kind]; ok && current != nil {
+		copied := *current
+		template = &copied
+	}
+
+	if err := edit(template); err != nil {
+		return err
+	}
+
+	if template.IsEmpty() {
+		delete(templates, kind)
+	} else {
+		templates[kind] = template
+	}
+
+	return d.ChangeSetting(guildID, userID, "embed_templates", templates)
+}
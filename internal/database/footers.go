@@ -0,0 +1,72 @@
+package database
+
+import (
+	"fmt"
+)
+
+//Footer modes decide where embed footers come from.
+const (
+	FootersDefault = "default"
+	FootersCustom  = "custom"
+	FootersMixed   = "mixed"
+	FootersOff     = "off"
+)
+
+//MaxFooters is the maximum number of server's own footer messages.
+const MaxFooters = 50
+
+//FooterMessage is a server's own embed footer message.
+type FooterMessage struct {
+	Content string `bson:"content" json:"content"`
+	NSFW    bool   `bson:"nsfw" json:"nsfw"`
+}
+
+//FooterMode returns guild's footer mode. Guilds created before the setting use bot's footers.
+func (gs *GuildSettings) FooterMode() string {
+	if gs.Footer == "" {
+		return FootersDefault
+	}
+
+	return gs.Footer
+}
+
+//AddFooter adds a message to server's footer pool.
+func (d *Database) AddFooter(guildID, userID string, footer *FooterMessage) error {
+	guild, ok := GuildCache[guildID]
+	if !ok {
+		return fmt.Errorf("Guild not found: %v", guildID)
+	}
+
+	if len(guild.Footers) >= MaxFooters {
+		return fmt.Errorf("server can't have more than %v footer messages", MaxFooters)
+	}
+
+	footers := make([]*FooterMessage, 0, len(guild.Footers)+1)
+	footers = append(footers, guild.Footers...)
+	footers = append(footers, footer)
+
+	return d.ChangeSetting(guildID, userID, "footers", footers)
+}
+
+//RemoveFooter removes a message from server's footer pool by its index. Messages are numbered from 1.
+func (d *Database) RemoveFooter(guildID, userID string, index int) (*FooterMessage, error) {
+	guild, ok := GuildCache[guildID]
+	if !ok {
+		return nil, fmt.Errorf("Guild not found: %v", guildID)
+	}
+
+	if index < 1 || index > len(guild.Footers) {
+		return nil, fmt.Errorf("footer message #%v doesn't exist", index)
+	}
+
+	removed := guild.Footers[index-1]
+	footers := make([]*FooterMessage, 0, len(guild.Footers)-1)
+	footers = append(footers, guild.Footers[:index-1]...)
+	footers = append(footers, guild.Footers[index:]...)
+
+	if err := d.ChangeSetting(guildID, userID, "footers", footers); err != nil {
+		return nil, err
+	}
+
+	return removed, nil
+}
@@ -25,8 +25,22 @@ type GuildSettings struct {
 	Crosspost     bool      `bson:"crosspost" json:"crosspost"`
 	NSFW          bool      `bson:"nsfw" json:"nsfw"`
 	Repost        string    `bson:"repost" json:"repost"`
+	UgoiraFormat  string    `bson:"ugoira" json:"ugoira"`
+	LargeSet      int       `bson:"largeset" json:"largeset"`
+	ReverseSearch string    `bson:"reversesearch" json:"reversesearch"`
+	PromptEmoji   string    `bson:"promptemoji" json:"promptemoji"`
+	Footer        string    `bson:"footer" json:"footer"`
+	Locale        string    `bson:"locale" json:"locale"`
+	ManagerRoles  []string  `bson:"manager_roles" json:"manager_roles"`
+	ChannelGroups []*Group  `bson:"channel_groups" json:"channel_groups"`
 	CreatedAt     time.Time `bson:"created_at" json:"created_at"`
 	UpdatedAt     time.Time `bson:"updated_at" json:"updated_at"`
+	//ChannelOverrides maps channel IDs to their settings overrides.
+	ChannelOverrides map[string]*ChannelOverride `bson:"channel_overrides,omitempty" json:"channel_overrides,omitempty"`
+	//EmbedTemplates maps embed kinds to their appearance templates.
+	EmbedTemplates map[string]*EmbedTemplate `bson:"embed_templates,omitempty" json:"embed_templates,omitempty"`
+	//Footers is server's own pool of embed footer messages.
+	Footers []*FooterMessage `bson:"footers,omitempty" json:"footers,omitempty"`
 }
 
 //DefaultGuildSettings returns a default GuildSettings struct.
@@ -41,6 +55,14 @@ func DefaultGuildSettings(guildID string) *GuildSettings {
 		Crosspost:     true,
 		NSFW:          true,
 		Repost:        "disabled",
+		UgoiraFormat:  "mp4",
+		LargeSet:      0,
+		ReverseSearch: "saucenao",
+		PromptEmoji:   "👌",
+		Footer:        FootersDefault,
+		Locale:        "en",
+		ManagerRoles:  make([]string, 0),
+		ChannelGroups: make([]*Group, 0),
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
@@ -96,22 +118,245 @@ func (d *Database) RemoveGuild(guildID string) error {
 	return nil
 }
 
-func (d *Database) ChangeSetting(guildID, setting string, newSetting interface{}) error {
-	res := d.GuildSettings.FindOneAndUpdate(context.Background(), bson.M{
+//ChangeSetting changes a guild setting on behalf of a user and records the change in settings history.
+//An old value is taken from the same update, so concurrent changes can't be recorded with a wrong old value.
+func (d *Database) ChangeSetting(guildID, userID, setting string, newSetting interface{}) error {
+	old := bson.M{}
+	err := d.GuildSettings.FindOneAndUpdate(context.Background(), bson.M{
 		"guild_id": guildID,
 	}, bson.M{
 		"$set": bson.M{
 			setting:      newSetting,
 			"updated_at": time.Now(),
 		},
-	}, options.FindOneAndUpdate().SetReturnDocument(options.After))
+		"$inc": bson.M{
+			"history_seq": 1,
+		},
+	}, options.FindOneAndUpdate().SetProjection(bson.M{setting: 1, "history_seq": 1}).SetReturnDocument(options.Before)).Decode(&old)
+	if err != nil {
+		return err
+	}
 
 	guild := &GuildSettings{}
-	err := res.Decode(guild)
+	err = d.GuildSettings.FindOne(context.Background(), bson.M{"guild_id": guildID}).Decode(guild)
 	if err != nil {
 		return err
 	}
 
 	GuildCache[guildID] = guild
-	return nil
+	return d.recordChange(&SettingChange{
+		Seq:       historySeq(old) + 1,
+		GuildID:   guildID,
+		UserID:    userID,
+		Setting:   setting,
+		OldValue:  old[setting],
+		NewValue:  newSetting,
+		CreatedAt: time.Now(),
+	})
+}
+
+//historySeq returns a number of the latest history entry of a guild document.
+func historySeq(doc bson.M) int {
+	switch seq := doc["history_seq"].(type) {
+	case int32:
+		return int(seq)
+	case int64:
+		return int(seq)
+	}
+
+	return 0
+}
+
+//SearchEngine returns guild's default reverse image search engine. Guilds created before the setting use SauceNAO.
+func (gs *GuildSettings) SearchEngine() string {
+	if gs.ReverseSearch == "" {
+		return "saucenao"
+	}
+
+	return gs.ReverseSearch
+}
+
+//ConfirmEmoji returns guild's confirmation prompt emoji, either unicode or name:ID of a server emoji.
+//It's safe to call on nil settings.
+func (gs *GuildSettings) ConfirmEmoji() string {
+	if gs == nil || gs.PromptEmoji == "" {
+		return "👌"
+	}
+
+	return gs.PromptEmoji
+}
+
+//FindGroup finds a server cross-post group by its name.
+func (gs *GuildSettings) FindGroup(name string) (*Group, int) {
+	for ind, group := range gs.ChannelGroups {
+		if group.Name == name {
+			return group, ind
+		}
+	}
+
+	return nil, -1
+}
+
+//CreateGuildGroup creates a server cross-post group.
+func (d *Database) CreateGuildGroup(guildID, userID, groupName, parentID string) error {
+	guild, ok := GuildCache[guildID]
+	if !ok {
+		return fmt.Errorf("Guild not found: %v", guildID)
+	}
+
+	if g, _ := guild.FindGroup(groupName); g != nil {
+		return fmt.Errorf("Group %v already exists", groupName)
+	}
+
+	groups := append(copyGroups(guild.ChannelGroups), &Group{Name: groupName, Parent: parentID, Children: make([]string, 0)})
+	return d.ChangeSetting(guildID, userID, "channel_groups", groups)
+}
+
+//DeleteGuildGroup deletes a server cross-post group.
+func (d *Database) DeleteGuildGroup(guildID, userID, groupName string) error {
+	guild, ok := GuildCache[guildID]
+	if !ok {
+		return fmt.Errorf("Guild not found: %v", guildID)
+	}
+
+	_, ind := guild.FindGroup(groupName)
+	if ind == -1 {
+		return fmt.Errorf("Group doesn't exist: %v", groupName)
+	}
+
+	groups := make([]*Group, 0, len(guild.ChannelGroups)-1)
+	groups = append(groups, guild.ChannelGroups[:ind]...)
+	groups = append(groups, guild.ChannelGroups[ind+1:]...)
+	return d.ChangeSetting(guildID, userID, "channel_groups", groups)
+}
+
+//AddToGuildGroup adds channels to a server cross-post group and returns added channels.
+func (d *Database) AddToGuildGroup(guildID, userID, groupName string, channelIDs ...string) ([]string, error) {
+	guild, ok := GuildCache[guildID]
+	if !ok {
+		return nil, fmt.Errorf("Guild not found: %v", guildID)
+	}
+
+	groups, group := editableGroup(guild, groupName)
+	if group == nil {
+		return nil, fmt.Errorf("Group doesn't exist: %v", groupName)
+	}
+
+	exists := make(map[string]bool)
+	for _, c := range group.Members() {
+		exists[c] = true
+	}
+
+	added := make([]string, 0)
+	for _, c := range channelIDs {
+		if !exists[c] {
+			exists[c] = true
+			added = append(added, c)
+		}
+	}
+
+	if len(added) == 0 {
+		return added, nil
+	}
+
+	group.Children = append(group.Children, added...)
+	return added, d.ChangeSetting(guildID, userID, "channel_groups", groups)
+}
+
+//RemoveFromGuildGroup removes channels from a server cross-post group and returns removed channels.
+func (d *Database) RemoveFromGuildGroup(guildID, userID, groupName string, channelIDs ...string) ([]string, error) {
+	guild, ok := GuildCache[guildID]
+	if !ok {
+		return nil, fmt.Errorf("Guild not found: %v", guildID)
+	}
+
+	groups, group := editableGroup(guild, groupName)
+	if group == nil {
+		return nil, fmt.Errorf("Group doesn't exist: %v", groupName)
+	}
+
+	var (
+		remove   = make(map[string]bool)
+		removed  = make([]string, 0)
+		children = make([]string, 0, len(group.Children))
+	)
+
+	for _, c := range channelIDs {
+		remove[c] = true
+	}
+
+	for _, c := range group.Children {
+		if remove[c] {
+			removed = append(removed, c)
+		} else {
+			children = append(children, c)
+		}
+	}
+
+	if len(removed) == 0 {
+		return removed, nil
+	}
+
+	for _, c := range removed {
+		delete(group.Filters, c)
+	}
+
+	group.Children = children
+	return removed, d.ChangeSetting(guildID, userID, "channel_groups", groups)
+}
+
+//SetGuildGroupFilter sets a filter of a child channel in a server cross-post group. Empty filter removes it.
+func (d *Database) SetGuildGroupFilter(guildID, userID, groupName, channelID string, filter *Filter) error {
+	guild, ok := GuildCache[guildID]
+	if !ok {
+		return fmt.Errorf("Guild not found: %v", guildID)
+	}
+
+	groups, group := editableGroup(guild, groupName)
+	if group == nil {
+		return fmt.Errorf("Group doesn't exist: %v", groupName)
+	}
+
+	group.SetFilter(channelID, filter)
+	return d.ChangeSetting(guildID, userID, "channel_groups", groups)
+}
+
+//EditGuildGroup applies changes to a server cross-post group and saves it.
+func (d *Database) EditGuildGroup(guildID, userID, groupName string, edit func(*Group) error) error {
+	guild, ok := GuildCache[guildID]
+	if !ok {
+		return fmt.Errorf("Guild not found: %v", guildID)
+	}
+
+	groups, group := editableGroup(guild, groupName)
+	if group == nil {
+		return fmt.Errorf("Group doesn't exist: %v", groupName)
+	}
+
+	if err := edit(group); err != nil {
+		return err
+	}
+
+	return d.ChangeSetting(guildID, userID, "channel_groups", groups)
+}
+
+//copyGroups deep-copies cross-post groups. Cache is replaced by ChangeSetting, so cached groups are left untouched if saving fails.
+func copyGroups(groups []*Group) []*Group {
+	copied := make([]*Group, 0, len(groups)+1)
+	for _, g := range groups {
+		copied = append(copied, g.Copy())
+	}
+
+	return copied
+}
+
+//editableGroup returns a copy of server's groups and the named group in it, nil if there's no such group.
+func editableGroup(guild *GuildSettings, groupName string) ([]*Group, *Group) {
+	_, ind := guild.FindGroup(groupName)
+	if ind == -1 {
+		return nil, nil
+	}
+
+	groups := copyGroups(guild.ChannelGroups)
+	return groups, groups[ind]
 }
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//SettingChange is an audit entry of a guild setting change.
+type SettingChange struct {
+	//Seq numbers entries of a guild sequentially. Numbers don't change when new entries are added.
+	Seq       int         `bson:"seq" json:"seq"`
+	GuildID   string      `bson:"guild_id" json:"guild_id"`
+	UserID    string      `bson:"user_id" json:"user_id"`
+	Setting   string      `bson:"setting" json:"setting"`
+	OldValue  interface{} `bson:"old_value" json:"old_value"`
+	NewValue  interface{} `bson:"new_value" json:"new_value"`
+	CreatedAt time.Time   `bson:"created_at" json:"created_at"`
+}
+
+func (d *Database) recordChange(change *SettingChange) error {
+	_, err := d.history.InsertOne(context.Background(), change)
+	if err != nil {
+		return fmt.Errorf("setting has been changed, but couldn't be saved to history: %v", err)
+	}
+
+	return nil
+}
+
+//SettingsHistory returns up to limit most recent setting changes of a guild, newest first.
+//Entries are ordered by their numbers, clocks of different instances can disagree. Unnumbered old entries come last.
+func (d *Database) SettingsHistory(guildID string, limit int) ([]*SettingChange, error) {
+	sort := bson.D{{Key: "seq", Value: -1}, {Key: "created_at", Value: -1}}
+	cur, err := d.history.Find(context.Background(), bson.M{"guild_id": guildID}, options.Find().SetSort(sort).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*SettingChange, 0)
+	err = cur.All(context.Background(), &changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+//FindSettingChange finds a history entry of a guild by its number.
+func (d *Database) FindSettingChange(guildID string, seq int) (*SettingChange, error) {
+	change := &SettingChange{}
+	err := d.history.FindOne(context.Background(), bson.M{"guild_id": guildID, "seq": seq}).Decode(change)
+	if err != nil {
+		return nil, fmt.Errorf("history entry #%v doesn't exist", seq)
+	}
+
+	return change, nil
+}
+
+//RollbackSetting restores a value a setting had before a history entry. Rollback is recorded as a new change.
+//Lists are stored whole, e.g. rolling back a change of one server cross-post group restores every group as it was.
+func (d *Database) RollbackSetting(guildID, userID string, change *SettingChange) error {
+	return d.ChangeSetting(guildID, userID, change.Setting, change.OldValue)
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -15,12 +16,39 @@ type UserSettings struct {
 	ID            string   `json:"user_id" bson:"user_id"`
 	Crosspost     bool     `json:"crosspost" bson:"crosspost"`
 	ChannelGroups []*Group `json:"channel_groups" bson:"channel_groups"`
+	//PausedUntil temporarily disables cross-posting of all user's posts.
+	PausedUntil time.Time `json:"paused_until" bson:"paused_until"`
+	//Locale is user's language. Server's language is used if it's empty.
+	Locale string `json:"locale,omitempty" bson:"locale,omitempty"`
 }
 
 type Group struct {
 	Name     string   `json:"name" bson:"name"`
 	Parent   string   `json:"parent" bson:"parent"`
 	Children []string `json:"children" bson:"children"`
+	//Filters maps child channel IDs to their filters.
+	Filters map[string]*Filter `json:"filters,omitempty" bson:"filters,omitempty"`
+	//Webhook makes cross-posts appear under requester's name and avatar.
+	Webhook bool `json:"webhook" bson:"webhook"`
+	//Parents are additional source channels besides Parent.
+	Parents []string `json:"parents,omitempty" bson:"parents,omitempty"`
+	//Mesh makes every channel of a group both a source and a destination.
+	Mesh bool `json:"mesh" bson:"mesh"`
+	//NoAttachments stops re-uploading image attachments of posts.
+	NoAttachments bool `json:"no_attachments" bson:"no_attachments"`
+	//Disabled and PausedUntil turn off a group without deleting it.
+	Disabled    bool      `json:"disabled" bson:"disabled"`
+	PausedUntil time.Time `json:"paused_until" bson:"paused_until"`
+}
+
+//Active reports whether a group is neither disabled nor paused.
+func (g *Group) Active() bool {
+	return !g.Disabled && time.Now().After(g.PausedUntil)
+}
+
+//CrosspostEnabled reports whether user's posts can be cross-posted right now.
+func (us *UserSettings) CrosspostEnabled() bool {
+	return us.Crosspost && time.Now().After(us.PausedUntil)
 }
 
 func NewUserSettings(id string) *UserSettings {
@@ -89,13 +117,9 @@ func (d *Database) CreateGroup(userID string, groupName string, parentID string)
 		if g.Name == groupName {
 			return fmt.Errorf("Group %v already exists", groupName)
 		}
-
-		if g.Parent == parentID {
-			return fmt.Errorf("Group with a parent channel ID [%v] already exists", parentID)
-		}
 	}
 
-	user.ChannelGroups = append(user.ChannelGroups, &Group{groupName, parentID, make([]string, 0)})
+	user.ChannelGroups = append(user.ChannelGroups, &Group{Name: groupName, Parent: parentID, Children: make([]string, 0)})
 	res := d.UserSettings.FindOneAndReplace(context.Background(), bson.M{"user_id": userID}, user)
 	if res.Err() != nil {
 		return res.Err()
@@ -114,10 +138,6 @@ func (d *Database) PushGroup(userID string, group *Group) error {
 		if g.Name == group.Name {
 			return fmt.Errorf("Group %v already exists", group.Name)
 		}
-
-		if g.Parent == group.Parent {
-			return fmt.Errorf("Group with a parent channel ID [%v] already exists", group.Parent)
-		}
 	}
 
 	user.ChannelGroups = append(user.ChannelGroups, group)
@@ -170,7 +190,7 @@ func (d *Database) AddToGroup(userID string, groupName string, channelIDs ...str
 	}
 
 	for _, c := range channelIDs {
-		if c != group.Parent {
+		if !group.IsSource(c) {
 			added = append(added, c)
 			group.Children = append(group.Children, c)
 		}
@@ -201,6 +221,7 @@ func (d *Database) RemoveFromGroup(userID string, groupName string, channelID ..
 			for ind, channel := range group.Children {
 				if channel == id {
 					found = append(found, group.Children[ind])
+					delete(group.Filters, id)
 					group.Children = append(group.Children[:ind], group.Children[ind+1:]...)
 					break
 				}
@@ -219,31 +240,127 @@ func (d *Database) RemoveFromGroup(userID string, groupName string, channelID ..
 	return found, nil
 }
 
-func (us *UserSettings) FindGroup(name string) (*Group, int) {
-	for ind, group := range us.ChannelGroups {
-		if group.Name == name {
-			return group, ind
-		}
+//SetGroupFilter sets a filter of a child channel in a cross-post group. Empty filter removes it.
+func (d *Database) SetGroupFilter(userID, groupName, channelID string, filter *Filter) error {
+	user := d.FindUser(userID)
+	if user == nil {
+		return fmt.Errorf("User not found: %v", userID)
 	}
 
-	return nil, -1
+	group, _ := user.FindGroup(groupName)
+	if group == nil {
+		return fmt.Errorf("Group doesn't exist: %v", groupName)
+	}
+
+	group.SetFilter(channelID, filter)
+	res := d.UserSettings.FindOneAndReplace(context.Background(), bson.M{"user_id": userID}, user)
+	if res.Err() != nil {
+		return res.Err()
+	}
+
+	return nil
 }
 
-func (us *UserSettings) findParent(parent string) (*Group, int) {
-	for ind, group := range us.ChannelGroups {
-		if group.Parent == parent {
-			return group, ind
-		}
+//EditGroup applies changes to a cross-post group and saves it.
+func (d *Database) EditGroup(userID, groupName string, edit func(*Group) error) error {
+	user := d.FindUser(userID)
+	if user == nil {
+		return fmt.Errorf("User not found: %v", userID)
 	}
 
-	return nil, -1
+	group, _ := user.FindGroup(groupName)
+	if group == nil {
+		return fmt.Errorf("Group doesn't exist: %v", groupName)
+	}
+
+	if err := edit(group); err != nil {
+		return err
+	}
+
+	res := d.UserSettings.FindOneAndReplace(context.Background(), bson.M{"user_id": userID}, user)
+	if res.Err() != nil {
+		return res.Err()
+	}
+
+	return nil
 }
 
-func (us *UserSettings) Channels(parent string) []string {
-	g, _ := us.findParent(parent)
+//ReplaceGroups replaces all cross-post groups of a user, user settings are created if they don't exist.
+func (d *Database) ReplaceGroups(userID string, groups []*Group) error {
+	user := d.FindUser(userID)
+	if user == nil {
+		user = NewUserSettings(userID)
+		user.ChannelGroups = groups
+		return d.InsertOneUser(user)
+	}
+
+	user.ChannelGroups = groups
+	res := d.UserSettings.FindOneAndReplace(context.Background(), bson.M{"user_id": userID}, user)
+	if res.Err() != nil {
+		return res.Err()
+	}
+
+	return nil
+}
+
+//SetCrosspost turns cross-posting of user's posts on or off, or pauses it until a given time.
+func (d *Database) SetCrosspost(userID string, enabled bool, pausedUntil time.Time) error {
+	user := d.FindUser(userID)
+	if user == nil {
+		user = NewUserSettings(userID)
+		user.Crosspost = enabled
+		user.PausedUntil = pausedUntil
+		return d.InsertOneUser(user)
+	}
 
-	if g != nil {
-		return g.Children
+	user.Crosspost = enabled
+	user.PausedUntil = pausedUntil
+	res := d.UserSettings.FindOneAndReplace(context.Background(), bson.M{"user_id": userID}, user)
+	if res.Err() != nil {
+		return res.Err()
 	}
+
 	return nil
 }
+
+//SetLocale changes user's language. Empty locale makes user follow server's language.
+func (d *Database) SetLocale(userID, locale string) error {
+	user := d.FindUser(userID)
+	if user == nil {
+		user = NewUserSettings(userID)
+		user.Locale = locale
+		return d.InsertOneUser(user)
+	}
+
+	user.Locale = locale
+	res := d.UserSettings.FindOneAndReplace(context.Background(), bson.M{"user_id": userID}, user)
+	if res.Err() != nil {
+		return res.Err()
+	}
+
+	return nil
+}
+
+//Locales returns preferred locales of a user in a guild: user's own locale first, then guild's one. Unset locales are skipped.
+func Locales(guildID, userID string) []string {
+	locales := make([]string, 0, 2)
+	if user, ok := userCache[userID]; ok && user.Locale != "" {
+		locales = append(locales, user.Locale)
+	}
+
+	if guild, ok := GuildCache[guildID]; ok && guild.Locale != "" {
+		locales = append(locales, guild.Locale)
+	}
+
+	return locales
+}
+
+func (us *UserSettings) FindGroup(name string) (*Group, int) {
+	for ind, group := range us.ChannelGroups {
+		if group.Name == name {
+			return group, ind
+		}
+	}
+
+	return nil, -1
+}
@@ -0,0 +1,225 @@
+package dispatcher
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/VTGare/boe-tea-go/internal/locale"
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+//Default is a dispatcher used by prompts and widgets.
+var Default = New()
+
+//nonceSeparator separates a nonce from the rest of a custom ID.
+const nonceSeparator = "|"
+
+var nonceSeq uint64
+
+//Dispatcher routes reaction and component events to their waiters by message ID or custom ID nonce.
+//Handlers are registered once per session instead of once per awaited event.
+type Dispatcher struct {
+	mu         sync.Mutex
+	reactions  map[string]map[chan *discordgo.MessageReactionAdd]bool
+	components map[string]map[chan *discordgo.InteractionCreate]bool
+	nonces     map[string]map[chan *discordgo.InteractionCreate]bool
+
+	//Locales resolves preferred locales of a user. English is used if it's nil.
+	Locales func(guildID, userID string) []string
+}
+
+//New creates an empty dispatcher.
+func New() *Dispatcher {
+	return &Dispatcher{
+		reactions:  make(map[string]map[chan *discordgo.MessageReactionAdd]bool),
+		components: make(map[string]map[chan *discordgo.InteractionCreate]bool),
+		nonces:     make(map[string]map[chan *discordgo.InteractionCreate]bool),
+	}
+}
+
+//NewNonce returns a unique nonce for custom IDs of components. Nonces of different processes don't collide.
+func NewNonce() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 36) + "." + strconv.FormatUint(atomic.AddUint64(&nonceSeq, 1), 36)
+}
+
+//WithNonce prefixes a custom ID with a nonce.
+func WithNonce(nonce, customID string) string {
+	return nonce + nonceSeparator + customID
+}
+
+//StripNonce returns a custom ID without its nonce.
+func StripNonce(customID string) string {
+	if ind := strings.Index(customID, nonceSeparator); ind != -1 {
+		return customID[ind+1:]
+	}
+
+	return customID
+}
+
+//nonceOf returns a nonce of a custom ID, empty string if it has none.
+func nonceOf(customID string) string {
+	if ind := strings.Index(customID, nonceSeparator); ind != -1 {
+		return customID[:ind]
+	}
+
+	return ""
+}
+
+//Register adds dispatcher's event handlers to a session.
+func (d *Dispatcher) Register(s *discordgo.Session) {
+	s.AddHandler(d.reactionAdded)
+	s.AddHandler(d.interactionCreated)
+}
+
+//Reactions subscribes to reactions added to a message. Cancel function must be called once a waiter is done, e.g. on timeout.
+func (d *Dispatcher) Reactions(messageID string) (<-chan *discordgo.MessageReactionAdd, func()) {
+	ch := make(chan *discordgo.MessageReactionAdd, 8)
+
+	d.mu.Lock()
+	if d.reactions[messageID] == nil {
+		d.reactions[messageID] = make(map[chan *discordgo.MessageReactionAdd]bool)
+	}
+	d.reactions[messageID][ch] = true
+	d.mu.Unlock()
+
+	return ch, func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		delete(d.reactions[messageID], ch)
+		if len(d.reactions[messageID]) == 0 {
+			delete(d.reactions, messageID)
+		}
+	}
+}
+
+//Components subscribes to component interactions on a message. Cancel function must be called once a waiter is done, e.g. on timeout.
+func (d *Dispatcher) Components(messageID string) (<-chan *discordgo.InteractionCreate, func()) {
+	return d.subscribe(d.components, messageID)
+}
+
+//ComponentsByNonce subscribes to interactions with components whose custom IDs carry a nonce, see WithNonce.
+//Unlike Components it can be called before a message is sent, so no early interaction is missed.
+func (d *Dispatcher) ComponentsByNonce(nonce string) (<-chan *discordgo.InteractionCreate, func()) {
+	return d.subscribe(d.nonces, nonce)
+}
+
+func (d *Dispatcher) subscribe(waiters map[string]map[chan *discordgo.InteractionCreate]bool, key string) (<-chan *discordgo.InteractionCreate, func()) {
+	ch := make(chan *discordgo.InteractionCreate, 8)
+
+	d.mu.Lock()
+	if waiters[key] == nil {
+		waiters[key] = make(map[chan *discordgo.InteractionCreate]bool)
+	}
+	waiters[key][ch] = true
+	d.mu.Unlock()
+
+	return ch, func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		delete(waiters[key], ch)
+		if len(waiters[key]) == 0 {
+			delete(waiters, key)
+		}
+	}
+}
+
+//Len returns a number of messages and nonces with active waiters.
+func (d *Dispatcher) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.reactions) + len(d.components) + len(d.nonces)
+}
+
+//DispatchReaction delivers a reaction to waiters of its message. It never blocks, events for busy waiters are dropped.
+func (d *Dispatcher) DispatchReaction(r *discordgo.MessageReactionAdd) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	waiters, ok := d.reactions[r.MessageID]
+	for ch := range waiters {
+		select {
+		case ch <- r:
+		default:
+			logrus.Warnf("DispatchReaction(): waiter of message %v is busy, dropping reaction", r.MessageID)
+		}
+	}
+
+	return ok
+}
+
+//DispatchComponent delivers a component interaction to waiters of its message and of its custom ID nonce.
+//It never blocks, events for busy waiters are dropped.
+func (d *Dispatcher) DispatchComponent(i *discordgo.InteractionCreate) bool {
+	if i.Type != discordgo.InteractionMessageComponent || i.Message == nil {
+		return false
+	}
+
+	var nonce string
+	if data, ok := i.Data.(discordgo.MessageComponentInteractionData); ok {
+		nonce = nonceOf(data.CustomID)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	byMessage, ok := d.components[i.Message.ID]
+	byNonce, okNonce := d.nonces[nonce]
+	for _, waiters := range []map[chan *discordgo.InteractionCreate]bool{byMessage, byNonce} {
+		for ch := range waiters {
+			select {
+			case ch <- i:
+			default:
+				logrus.Warnf("DispatchComponent(): waiter of message %v is busy, dropping interaction", i.Message.ID)
+			}
+		}
+	}
+
+	return ok || okNonce
+}
+
+//UserLocales returns preferred locales of a user, nil if there's no Locales resolver.
+func (d *Dispatcher) UserLocales(guildID, userID string) []string {
+	if d.Locales == nil {
+		return nil
+	}
+
+	return d.Locales(guildID, userID)
+}
+
+func (d *Dispatcher) reactionAdded(_ *discordgo.Session, r *discordgo.MessageReactionAdd) {
+	d.DispatchReaction(r)
+}
+
+func (d *Dispatcher) interactionCreated(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+
+	//nobody waits for components of expired prompts and widgets
+	if !d.DispatchComponent(i) {
+		user := i.User
+		if i.Member != nil {
+			user = i.Member.User
+		}
+
+		var locales []string
+		if user != nil {
+			locales = d.UserLocales(i.GuildID, user.ID)
+		}
+
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{Content: locale.Get(locales, "prompt.expired"), Flags: discordgo.MessageFlagsEphemeral},
+		})
+		if err != nil {
+			logrus.Warnf("InteractionRespond(): %v", err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func reaction(messageID string) *discordgo.MessageReactionAdd {
+	return &discordgo.MessageReactionAdd{MessageReaction: &discordgo.MessageReaction{MessageID: messageID}}
+}
+
+func component(messageID string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type:    discordgo.InteractionMessageComponent,
+		Message: &discordgo.Message{ID: messageID},
+	}}
+}
+
+func TestReactions(t *testing.T) {
+	d := New()
+	ch, cancel := d.Reactions("1")
+
+	if d.DispatchReaction(reaction("2")) {
+		t.Errorf("DispatchReaction() delivered a reaction of unrelated message")
+	}
+
+	if !d.DispatchReaction(reaction("1")) {
+		t.Fatalf("DispatchReaction() = false, want true")
+	}
+
+	select {
+	case r := <-ch:
+		if r.MessageID != "1" {
+			t.Errorf("received reaction of message %v, want 1", r.MessageID)
+		}
+	default:
+		t.Fatalf("reaction has not been delivered")
+	}
+
+	cancel()
+	if d.Len() != 0 {
+		t.Errorf("Len() = %v after cancel, want 0", d.Len())
+	}
+
+	if d.DispatchReaction(reaction("1")) {
+		t.Errorf("DispatchReaction() delivered a reaction after cancel")
+	}
+}
+
+func TestComponents(t *testing.T) {
+	d := New()
+	first, cancelFirst := d.Components("1")
+	second, cancelSecond := d.Components("1")
+	defer cancelSecond()
+
+	if !d.DispatchComponent(component("1")) {
+		t.Fatalf("DispatchComponent() = false, want true")
+	}
+
+	for _, ch := range []<-chan *discordgo.InteractionCreate{first, second} {
+		select {
+		case <-ch:
+		default:
+			t.Fatalf("interaction has not been delivered to every waiter")
+		}
+	}
+
+	cancelFirst()
+	if d.Len() != 1 {
+		t.Errorf("Len() = %v, want 1", d.Len())
+	}
+
+	commands := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{Type: discordgo.InteractionApplicationCommand}}
+	if d.DispatchComponent(commands) {
+		t.Errorf("DispatchComponent() delivered an application command")
+	}
+}
+
+func TestComponentsByNonce(t *testing.T) {
+	var (
+		d     = New()
+		nonce = NewNonce()
+	)
+
+	ch, cancel := d.ComponentsByNonce(nonce)
+
+	//subscribed before the message has been sent, its ID is unknown to the waiter
+	i := component("1")
+	i.Data = discordgo.MessageComponentInteractionData{CustomID: WithNonce(nonce, "prompt:confirm:")}
+	if !d.DispatchComponent(i) {
+		t.Fatalf("DispatchComponent() = false, want true")
+	}
+
+	select {
+	case got := <-ch:
+		if id := StripNonce(got.MessageComponentData().CustomID); id != "prompt:confirm:" {
+			t.Errorf("StripNonce() = %v, want prompt:confirm:", id)
+		}
+	default:
+		t.Fatalf("interaction has not been delivered")
+	}
+
+	other := component("1")
+	other.Data = discordgo.MessageComponentInteractionData{CustomID: WithNonce(NewNonce(), "prompt:confirm:")}
+	if d.DispatchComponent(other) {
+		t.Errorf("DispatchComponent() delivered an interaction with another nonce")
+	}
+
+	cancel()
+	if d.Len() != 0 {
+		t.Errorf("Len() = %v after cancel, want 0", d.Len())
+	}
+}
+
+func TestDispatchNeverBlocks(t *testing.T) {
+	d := New()
+	_, cancel := d.Reactions("1")
+	defer cancel()
+
+	//nobody reads from the channel, extra events are dropped
+	for i := 0; i < 100; i++ {
+		d.DispatchReaction(reaction("1"))
+	}
+}
@@ -0,0 +1,283 @@
+package locale
+
+var en = map[string]string{
+	"error.title":                "Oops, something went wrong!",
+	"error.description":          "***Error message:***\n%v\n\nPlease contact bot's author using bt!feedback command or directly at VTGare#3599 if you can't understand the error.",
+	"error.not_enough_arguments": "not enough arguments",
+	"error.parsing_argument":     "error parsing arguments, please make sure all arguments are integers",
+	"error.no_permission":        "you don't have permissions to execute this command",
+	"error.database":             "Fatal database error: %v",
+	"command.failed":             "❎ Failed to execute a command.",
+	"command.reason":             "Reason",
+
+	"prompt.confirm":   "Confirm",
+	"prompt.cancel":    "Cancel",
+	"prompt.pick":      "Pick options",
+	"prompt.failed":    "Error while creating a prompt",
+	"prompt.not_yours": "❎ These controls belong to someone else.",
+	"prompt.expired":   "❎ These controls have expired.",
+
+	"slash.unknown":       "❎ Unknown command.",
+	"slash.guild_only":    "❎ %v command can only be used in a server.",
+	"slash.nsfw":          "❎ NSFW commands can only be used in NSFW channels.",
+	"slash.cooldown":      "Please wait %v before executing %v command again.",
+	"widget.previous":     "Previous",
+	"widget.next":         "Next",
+	"pixiv.link_required": "First argument **must** be a Pixiv link.",
+	"ugoira.rendering":    "⏳ Rendering animation...",
+	"ugoira.queued":       "⏳ Waiting for other animations to render. Position in queue: %v",
+
+	"repost.title":             "General Reposti!",
+	"repost.description":       "***Reminder:*** you can look up if things you post have already been posted using Discord's search feature.\nI recommend to check reposts by post's unique identifier.",
+	"repost.content":           "Content",
+	"repost.link":              "Link to post",
+	"repost.link_value":        "[Press here desu~](%v)",
+	"repost.expires":           "Expires",
+	"repost.prompt":            "Following posts are reposts, confirm to post them.",
+	"repost.strict_permission": "Please enable Manage Messages permission to remove reposts with strict mode on, otherwise strict mode is useless.",
+	"repost.large_set":         "Album size (%v) is considered large on this server, are you sure you want to post it?",
+	"repost.limit":             "```Album size (%v) is larger than limit set on this server (%v), only first image of every post is reposted.```",
+	"repost.pick_pages":        "Album size (%v) is larger than limit set on this server (%v). Pick up to %v pages to post, otherwise only the first image is reposted.",
+	"repost.page":              "Page %v",
+	"repost.tweet_prompt":      "Detected a tweet with more than one image, would you like to send embeds of other images for mobile users?",
+	"repost.tweets_prompt":     "Detected tweets with more than one image, would you like to send embeds of other images for mobile users?",
+	"repost.tweet_repost":      "Tweet you're trying to post is a repost. Are you sure about that?",
+
+	"nsfw.title":             "❎ Pixiv post has not been reposted.",
+	"nsfw.reason":            "Reason",
+	"nsfw.server":            "An NSFW post has been detected. The server prohibits NSFW content.",
+	"nsfw.channel":           "An NSFW post has been detected. The channel is not marked as NSFW.",
+	"nsfw.prompt":            "You're trying to send an NSFW post in a SFW channel, are you sure about that?",
+	"nsfw.command":           "You're trying to execute an NSFW command. The server prohibits NSFW content.",
+	"crosspost.skipped":      "❎ Some channels have been skipped.",
+	"crosspost.disabled":     "Cross-posting is disabled on the server.",
+	"crosspost.no_pixiv":     "Pixiv reposting is disabled on the server.",
+	"crosspost.no_tweets":    "Twitter reposting is disabled on the server.",
+	"crosspost.requested_by": "Crosspost requested by %v",
+	"crosspost.off":          "Cross-posting of your posts is turned off. Use ``bt!toggle`` to turn it on",
+	"crosspost.paused":       "Cross-posting of your posts is paused until %v. Use ``bt!resume`` to resume it",
+
+	"server.title":             "Server cross-post groups",
+	"server.empty":             "This server has no cross-post groups. Administrators and bot managers can create one using ``bt!server create <group name> <parent channel>``",
+	"server.created":           "✅ Successfully created a server cross-post group!",
+	"server.deleted":           "✅ Successfully deleted a server cross-post group!",
+	"server.added":             "✅ Successfully added channels to a server cross-post group!",
+	"server.removed":           "✅ Successfully removed channels from a server cross-post group!",
+	"server.edit_failed":       "❎ Failed to edit a server cross-post group!",
+	"server.source_usage":      "``bt!server source`` requires a group name, an action and channels.\n**Usage:** ``bt!server source <group name> <add | remove> [channels]``",
+	"server.group_required":    "``bt!server %v`` requires a group name",
+	"server.duration_required": "``bt!server pause`` requires a duration. Example: ``bt!server pause art 2h``",
+	"server.usage":             "``bt!server %v`` requires a group name.\n**Usage:** ``bt!server <create | delete | push | pop> <group name> [channels]``",
+	"server.one_parent":        "``bt!server create`` requires exactly one parent channel. Example: ``bt!server create art #art``",
+	"server.channels_required": "``bt!server %v`` requires at least one channel",
+	"server.unknown_action":    "unknown action ``%v``. Please use bt!help server command for more information",
+	"server.filter_usage":      "``bt!server filter`` requires at least three arguments.\n**Usage:** ``bt!server filter <group name> <channel> <rule> [values]``",
+	"server.not_found":         "Server cross-post group **%v** has not been found",
+	"server.set_usage":         "``bt!server set`` requires three arguments.\n**Usage:** ``bt!server set <group name> <setting> <value>``",
+	"server.foreign_channel":   "channel <#%v> belongs to a different server. Server groups can only use this server's channels",
+
+	"group.name":                   "Name",
+	"group.group_name":             "Group name",
+	"group.parent":                 "Parent",
+	"group.parent_channel":         "Parent channel",
+	"group.channels":               "Channels",
+	"group.status":                 "Status",
+	"group.mode":                   "Mode",
+	"group.all":                    "All groups",
+	"group.no_channels":            "No valid channels were found",
+	"group.no_groups":              "You have no cross-post groups yet.",
+	"group.not_found":              "Cross-post group **%v** has not been found.",
+	"group.no_user":                "user settings not found, create a group first with the following command: ``bt!create <group name> <parent ID>``",
+	"group.title":                  "%v's cross-post groups",
+	"group.empty":                  ":gun:🤠 *This town ain't big enough for the both of us!*\n",
+	"group.description":            "**Parent:** [<#%v>]\n**Children:** %v",
+	"group.status_disabled":        "**Status:** disabled",
+	"group.status_paused":          "**Status:** paused until %v",
+	"group.sources":                "**Other sources:** %v",
+	"group.mesh":                   "**Mesh:** on",
+	"group.webhook":                "**Webhook:** on",
+	"group.no_attachments":         "**Attachments:** off",
+	"group.unknown_channel":        "unable to find channel ``%v``. Make sure Boe Tea is present on the server and able to read the channel",
+	"group.skipped_channel":        "``%v``: unable to find the channel. Make sure Boe Tea is present on the server and able to read the channel",
+	"group.create_usage":           "``bt!create`` requires two arguments. Example: ``bt!create touhou #lewdtouhouart``",
+	"group.created":                "✅ Successfully created a cross-post group!",
+	"group.delete_usage":           "``bt!delete`` requires at least one argument.\n**Usage:** ``bt!delete ntr``",
+	"group.delete_failed":          "❎ Failed to delete a cross-post group!",
+	"group.deleted":                "✅ Successfully deleted a cross-post group!",
+	"group.remove_usage":           "``bt!remove`` requires at least two arguments.\n**Usage:** ``bt!remove nudes #nsfw``",
+	"group.remove_failed":          "❎ Failed to remove from a cross-post group!",
+	"group.removed":                "✅ Successfully removed channels from a cross-post group!",
+	"group.remove_channels_failed": "❎ Failed to remove channels from a cross-post group!",
+	"group.add_usage":              "``bt!push`` requires at least two arguments.\n**Usage:** ``bt!push hololive #marine-booty``",
+	"group.add_failed":             "❎ Failed to add to a cross-post group!",
+	"group.already_member":         "Channel <#%v> is already part of group %v",
+	"group.added":                  "✅ Successfully added channels to a cross-post group!",
+	"group.add_channels_failed":    "❎ Failed to add channels to a cross-post group!",
+	"group.skipped_channels":       "Skipped channels",
+	"group.copy_usage":             "``bt!copy`` requires at least three arguments.\n**Usage:** ``bt!copy <source> <destination> <new parent channel>``",
+	"group.copy_failed":            "❎ Failed to copy a cross-post group!",
+	"group.no_source":              "Couldn't find a source group ``%v``",
+	"group.name_taken":             "Group name %v is already taken",
+	"group.copied":                 "✅ Successfully copied a cross-post group!",
+	"group.set_usage":              "``bt!groupset`` requires three arguments.\n**Usage:** ``bt!groupset <group name> <setting> <value>``",
+	"group.unknown_setting":        "unknown group setting ``%v``. Please use bt!help groupset command for more information",
+	"group.setting_changed":        "✅ Successfully changed a group setting!",
+	"group.nothing_to_export":      "You have no cross-post groups to export",
+	"group.exported":               "Exported %v cross-post groups. Use ``bt!crosspost import`` with this file attached to restore them.",
+	"group.bad_import_mode":        "unknown import mode ``%v``. Please use ``merge`` or ``replace``",
+	"group.import_required":        "please attach a JSON file made by ``bt!crosspost export``",
+	"group.import_too_large":       "file is too large, maximum size is %v KB",
+	"group.import_download":        "unable to download the file: %v",
+	"group.import_read":            "unable to read cross-post groups: %v",
+	"group.unreachable_channel":    "``%v``: unable to find the channel",
+	"group.duplicate_name":         "%v: duplicate group name",
+	"group.unreachable_parent":     "%v: parent channel is unreachable",
+	"group.imported":               "✅ Successfully imported cross-post groups!",
+	"group.imported_count":         "Imported",
+	"group.groups":                 "Groups",
+	"group.unreachable":            "Unreachable channels",
+	"group.skipped_groups":         "Skipped groups",
+	"group.bad_duration":           "unable to parse duration ``%v``. Examples of valid durations: ``30m``, ``2h``, ``1d``",
+	"group.pause_usage":            "``bt!pause`` requires a duration.\n**Usage:** ``bt!pause <duration> [group name]``",
+	"group.paused_until":           "paused until %v",
+	"group.toggled_all":            "✅ Successfully toggled cross-posting!",
+	"group.paused_all":             "✅ Successfully paused cross-posting!",
+	"group.resumed_all":            "✅ Successfully resumed cross-posting!",
+	"group.toggled":                "✅ Successfully toggled a cross-post group!",
+	"group.paused":                 "✅ Successfully paused a cross-post group!",
+	"group.resumed":                "✅ Successfully resumed a cross-post group!",
+	"group.source_usage":           "``bt!source`` requires at least three arguments.\n**Usage:** ``bt!source <group name> <add | remove> [channels]``",
+	"group.bad_source_action":      "unknown action ``%v``. Please use ``add`` or ``remove``",
+	"group.sources_failed":         "❎ Failed to edit source channels!",
+	"group.sources_edited":         "✅ Successfully edited source channels!",
+	"filter.usage":                 "``bt!filter`` requires at least three arguments.\n**Usage:** ``bt!filter <group name> <channel> <rule> [values]``",
+	"filter.not_destination":       "channel <#%v> doesn't receive cross-posts of group %v",
+	"filter.rating_required":       "rating requires a value: ``sfw``, ``nsfw`` or ``any``",
+	"filter.bad_rating":            "unknown rating ``%v``. Please use ``sfw``, ``nsfw`` or ``any``",
+	"filter.bad_provider":          "unknown provider ``%v``. Please use ``pixiv``, ``twitter``, ``attachments`` or ``any``",
+	"filter.bad_rule":              "unknown rule ``%v``. Please use bt!help filter command for more information",
+	"filter.changed":               "✅ Successfully changed a cross-post filter!",
+	"filter.filter":                "Filter",
+	"filter.tags":                  "**Tags:** %v",
+	"filter.excluded":              "**Excluded tags:** %v",
+	"filter.rating":                "**Rating:** %v",
+	"filter.providers":             "**Providers:** %v",
+	"filter.likes":                 "**Likes:** %v+",
+
+	"settings.changed":   "✅ Successfully changed a setting!",
+	"settings.setting":   "Setting",
+	"settings.new_value": "New value",
+	"settings.bad_usage": "incorrect command usage. Please use bt!help set command for more information",
+	"settings.bad_name":  "invalid setting name: %v",
+
+	"settings.channel_changed": "✅ Successfully changed a channel setting!",
+	"settings.channel":         "Channel",
+	"settings.inherited":       "inherited from the server",
+	"settings.channel_title":   "Current channel settings",
+	"settings.overrides":       "Overrides",
+	"settings.effective":       "Effective settings",
+	"settings.bad_channel":     "unable to find channel ``%v`` on this server",
+	"settings.not_per_channel": "setting %v can't be changed per channel. Available settings: pixiv, twitter, twitterprompt, limit, repost, nsfw",
+	"settings.bad_largeset":    "large set size can't be negative, use 0 or off to disable it",
+	"settings.bad_footer":      "unknown option. footer only accepts default, custom, mixed and off options",
+	"settings.foreign_emoji":   "emoji is not from this server. Only unicode or this server's emojis are allowed",
+	"settings.not_emoji":       "%v is not an emoji. Only unicode or this server's emojis are allowed",
+	"managers.title":           "Bot managers",
+	"managers.roles":           "Roles",
+	"managers.changed":         "✅ Successfully changed bot managers!",
+	"managers.admins_only":     "Administrators only",
+	"managers.bad_role":        "unable to find role ``%v`` on this server",
+	"history.title":            "Settings history",
+	"history.empty":            "No settings have been changed yet.",
+	"history.entry":            "**#%v** ``%v`` by <@%v>, %v\n%v → %v",
+	"history.footer":           "Page %v/%v. Use bt!set rollback <entry> to restore an old value.",
+	"history.entry_required":   "history entry is required. Use bt!set history to find one",
+	"history.not_found":        "history entry #%v doesn't exist",
+	"history.rolled_back":      "✅ Successfully rolled back a setting!",
+	"history.restored":         "Restored value",
+	"footers.title":            "Footer messages",
+	"footers.empty":            "This server has no footer messages. Bot managers can add one using ``bt!set footers add [--nsfw] <message>``",
+	"footers.mode":             "Mode",
+	"footers.page":             "Page %v/%v",
+	"footers.added":            "✅ Successfully added a footer message!",
+	"footers.removed":          "✅ Successfully removed a footer message!",
+	"footers.required":         "footer message is required",
+	"footers.too_long":         "footer message can't be longer than 256 characters",
+	"embed.title":              "Embed templates",
+	"embed.kind_title":         "%v embed template",
+	"embed.template":           "Template",
+	"embed.fields":             "Fields",
+	"embed.embed":              "Embed",
+	"embed.changed":            "✅ Successfully changed an embed template!",
+	"embed.unknown":            "unknown embed ``%v``. Available embeds: pixiv, twitter, repost, sauce",
+	"embed.bad_color":          "``%v`` is not a valid hex colour, e.g. #439ef1",
+	"embed.bad_url":            "``%v`` is not an image URL",
+	"embed.unknown_field":      "unknown field ``%v``. Available fields: %v",
+	"embed.bad_tags":           "tag count must be a non-negative number or ``all``",
+	"embed.footer_too_long":    "footer text can't be longer than 2048 characters",
+	"embed.unknown_property":   "unknown template property ``%v``. Available properties: color, thumbnail, fields, tags, footer, reset",
+
+	"settings.title":                "Current settings",
+	"settings.general":              "General",
+	"settings.general_value":        "**Prefix:** %v | **NSFW:** %v",
+	"settings.features":             "Features",
+	"settings.features_value":       "**Repost:** %v | **Crosspost**: %v | **Reverse search**: %v | **Prompt emoji**: %v | **Footer**: %v | **Locale**: %v",
+	"settings.pixiv":                "Pixiv settings",
+	"settings.pixiv_value":          "**Auto-repost (pixiv)**: %v | **Limit**: %v | **Large set**: %v | **Ugoira**: %v",
+	"settings.twitter":              "Twitter settings",
+	"settings.twitter_value":        "**Auto-repost (twitter)**: %v | **Prompt**: %v",
+	"settings.channel_overrides":    "Channel overrides",
+	"settings.no_channel_overrides": "None. Use ``bt!set --channel <channel> <setting> <value>`` to add one.",
+	"settings.no_overrides":         "No overrides",
+	"settings.effective_value":      "**Pixiv**: %v | **Twitter**: %v | **Prompt**: %v | **Limit**: %v | **Repost**: %v | **NSFW**: %v",
+	"settings.enabled":              "enabled",
+	"settings.disabled":             "disabled",
+	"settings.unset":                "*unset*",
+	"settings.long_prefix":          "new prefix (%v) is too long (%v). Maximum length is %v",
+	"settings.bad_reverse_search":   "unknown option. reversesearch only accepts saucenao and wait options",
+	"settings.bad_repost":           "unknown option. repost only accepts enabled, disabled, and strict options",
+	"embed.default":                 "Default",
+	"embed.default_templates":       "Default. Use ``bt!set embed`` to customise embeds.",
+	"embed.customised":              "Customised: %v",
+	"consent.title":                 "Warning!",
+	"consent.description":           "Repost checking requires collecting following data. Do you agree sharing this information?",
+	"consent.content":               "Post content",
+	"consent.content_value":         "Pixiv ID or Twitter link. Essential for repost checking for obvious reasons",
+	"consent.date":                  "Date and time of posting",
+	"consent.date_value":            "Required to remove repost from a database in 24 hours",
+	"consent.username":              "Poster's username (without an ID or discriminator)",
+	"consent.username_value":        "Required to give more information about the original poster when repost is detected",
+	"consent.ids":                   "Guild ID, message ID, and channel ID",
+	"consent.ids_value":             "Essential for repost checking. Required to find a repost in a database and create a link to the original post.",
+	"consent.cancelled":             "cancelled enabling repost checker, ignore this error",
+
+	"locale.title":           "Language",
+	"locale.changed":         "✅ Successfully changed your language!",
+	"locale.current":         "Current language",
+	"locale.available":       "Available languages",
+	"locale.server":          "server's language",
+	"locale.unsupported":     "unsupported language ``%v``. Available languages: %v",
+	"locale.name":            "English",
+	"help.set.usage.name":    "Usage",
+	"help.set.usage":         "bt!set ``<setting>`` ``<new setting>``",
+	"help.set.managers.name": "Bot managers",
+	"help.set.managers":      "bt!set managers ``[<add | remove> <roles>]``. Members with these roles can change settings and server cross-post groups. Only administrators can change the list.",
+	"help.set.embed.name":    "Embed templates",
+	"help.set.embed":         "bt!set embed ``[<pixiv | twitter | repost | sauce> <property> <value>]``. Properties: ***color*** (hex or default), ***thumbnail*** (image URL, none or default), ***fields*** (fields to show, all or none), ***tags*** (tag count or all), ***footer*** (text, none or default), ***reset***.",
+	"help.set.footers.name":  "Footer messages",
+	"help.set.footers":       "bt!set footers ``[add [--nsfw] <message> | remove <number>]``. Manages server's own embed footers. ***NSFW*** messages are skipped in SFW channels.",
+	"help.set.history.name":  "History",
+	"help.set.history":       "``bt!set history`` lists recent changes of settings, ``bt!set rollback <entry>`` restores a value from before a change. Lists such as cross-post groups are restored as a whole. Requires a bot manager role.",
+	"help.set.channel.name":  "Channel overrides",
+	"help.set.channel":       "bt!set --channel ``<channel>`` ``[<setting> <new setting>]``. Overrides ***pixiv, twitter, twitterprompt, limit, repost, nsfw*** in one channel, ***inherit*** removes an override. Omit setting to show channel's settings.",
+	"help.set.prefix":        "Bot's prefix. Up to ***5 characters***. If last character is a letter whitespace is assumed (takes one character).",
+	"help.set.footer":        "Source of embed footers, valid parameters: ***[default, custom, mixed, off]***. ***custom*** uses only server's own messages, ***mixed*** adds them to bot's messages.",
+	"help.set.largeset":      "Album size considered as large and invokes a prompt when posted. ***0*** or ***off*** disables the prompt.",
+	"help.set.limit":         "Hard limit for album size. Only first image from an album will be posted if album size exceeded limit.",
+	"help.set.pixiv":         "Pixiv or Twitter reposting switch, valid parameters: ***[enabled, on, t, true], [disabled, off, f, false]***",
+	"help.set.repost":        "Repost check setting, valid parameters: ***[enabled, disabled, strict]***. Strict mode disables a prompt and removes reposts on sight.",
+	"help.set.ugoira":        "Pixiv animation format, valid parameters: ***[mp4, gif, webm, apng]***. Falls back to a smaller format or resolution if the file is too large.",
+	"help.set.reversesearch": "Default reverse image search engine. Available options: ***[saucenao, wait]***",
+	"help.set.promptemoji":   "Confirmation prompt emoji. Only unicode or local server emoji's are allowed.",
+	"help.set.locale":        "Server's language, valid parameters: ***[en, ja]***. Members can choose their own language with ``bt!locale``.",
+}
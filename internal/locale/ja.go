@@ -0,0 +1,283 @@
+package locale
+
+var ja = map[string]string{
+	"error.title":                "エラーが発生しました！",
+	"error.description":          "***エラーメッセージ：***\n%v\n\nエラーの内容が分からない場合は、bt!feedback コマンドまたは VTGare#3599 まで直接お問い合わせください。",
+	"error.not_enough_arguments": "引数が足りません",
+	"error.parsing_argument":     "引数を解析できませんでした。すべての引数が整数であることを確認してください",
+	"error.no_permission":        "このコマンドを実行する権限がありません",
+	"error.database":             "データベースエラー：%v",
+	"command.failed":             "❎ コマンドを実行できませんでした。",
+	"command.reason":             "理由",
+
+	"prompt.confirm":   "確認",
+	"prompt.cancel":    "キャンセル",
+	"prompt.pick":      "選択してください",
+	"prompt.failed":    "プロンプトの作成中にエラーが発生しました",
+	"prompt.not_yours": "❎ この操作は他のユーザー用です。",
+	"prompt.expired":   "❎ この操作は期限切れです。",
+
+	"slash.unknown":       "❎ 不明なコマンドです。",
+	"slash.guild_only":    "❎ %v コマンドはサーバー内でのみ使用できます。",
+	"slash.nsfw":          "❎ NSFWコマンドはNSFWチャンネルでのみ使用できます。",
+	"slash.cooldown":      "%[2]v コマンドを再度実行するには %[1]v お待ちください。",
+	"widget.previous":     "前へ",
+	"widget.next":         "次へ",
+	"pixiv.link_required": "最初の引数はPixivのリンクで**なければなりません**。",
+	"ugoira.rendering":    "⏳ アニメーションをレンダリングしています...",
+	"ugoira.queued":       "⏳ 他のアニメーションのレンダリングを待っています。待ち順：%v",
+
+	"repost.title":             "リポストです！",
+	"repost.description":       "***ヒント：*** Discordの検索機能で、投稿しようとしているものが既に投稿されていないか確認できます。\n投稿の固有IDで検索することをおすすめします。",
+	"repost.content":           "内容",
+	"repost.link":              "投稿へのリンク",
+	"repost.link_value":        "[ここをクリック](%v)",
+	"repost.expires":           "有効期限",
+	"repost.prompt":            "以下の投稿はリポストです。投稿するには確認してください。",
+	"repost.strict_permission": "strictモードでリポストを削除するには「メッセージの管理」権限を有効にしてください。権限がないとstrictモードは機能しません。",
+	"repost.large_set":         "アルバムのサイズ（%v）はこのサーバーでは大きいと見なされます。本当に投稿しますか？",
+	"repost.limit":             "```アルバムのサイズ（%v）がこのサーバーの上限（%v）を超えているため、各投稿の最初の画像のみ投稿されます。```",
+	"repost.pick_pages":        "アルバムのサイズ（%v）がこのサーバーの上限（%v）を超えています。投稿するページを最大%v個選んでください。選ばない場合は最初の画像のみ投稿されます。",
+	"repost.page":              "%vページ",
+	"repost.tweet_prompt":      "複数の画像を含むツイートが見つかりました。モバイルユーザー向けに他の画像の埋め込みを送信しますか？",
+	"repost.tweets_prompt":     "複数の画像を含むツイートが見つかりました。モバイルユーザー向けに他の画像の埋め込みを送信しますか？",
+	"repost.tweet_repost":      "投稿しようとしているツイートはリポストです。本当によろしいですか？",
+
+	"nsfw.title":             "❎ Pixivの投稿はリポストされませんでした。",
+	"nsfw.reason":            "理由",
+	"nsfw.server":            "NSFWの投稿が検出されました。このサーバーではNSFWコンテンツが禁止されています。",
+	"nsfw.channel":           "NSFWの投稿が検出されました。このチャンネルはNSFWに設定されていません。",
+	"nsfw.prompt":            "NSFWではないチャンネルにNSFWの投稿を送信しようとしています。本当によろしいですか？",
+	"nsfw.command":           "NSFWコマンドを実行しようとしています。このサーバーではNSFWコンテンツが禁止されています。",
+	"crosspost.skipped":      "❎ 一部のチャンネルはスキップされました。",
+	"crosspost.disabled":     "このサーバーではクロスポストが無効です。",
+	"crosspost.no_pixiv":     "このサーバーではPixivのリポストが無効です。",
+	"crosspost.no_tweets":    "このサーバーではTwitterのリポストが無効です。",
+	"crosspost.requested_by": "%v さんのリクエストによるクロスポスト",
+	"crosspost.off":          "あなたの投稿のクロスポストはオフになっています。``bt!toggle`` でオンにできます",
+	"crosspost.paused":       "あなたの投稿のクロスポストは %v まで一時停止されています。``bt!resume`` で再開できます",
+
+	"server.title":             "サーバーのクロスポストグループ",
+	"server.empty":             "このサーバーにはクロスポストグループがありません。管理者とボット管理者は ``bt!server create <グループ名> <親チャンネル>`` で作成できます",
+	"server.created":           "✅ サーバーのクロスポストグループを作成しました！",
+	"server.deleted":           "✅ サーバーのクロスポストグループを削除しました！",
+	"server.added":             "✅ サーバーのクロスポストグループにチャンネルを追加しました！",
+	"server.removed":           "✅ サーバーのクロスポストグループからチャンネルを削除しました！",
+	"server.edit_failed":       "❎ サーバーのクロスポストグループを編集できませんでした！",
+	"server.source_usage":      "``bt!server source`` にはグループ名、操作、チャンネルが必要です。\n**使い方：** ``bt!server source <グループ名> <add | remove> [チャンネル]``",
+	"server.group_required":    "``bt!server %v`` にはグループ名が必要です",
+	"server.duration_required": "``bt!server pause`` には期間が必要です。例：``bt!server pause art 2h``",
+	"server.usage":             "``bt!server %v`` にはグループ名が必要です。\n**使い方：** ``bt!server <create | delete | push | pop> <グループ名> [チャンネル]``",
+	"server.one_parent":        "``bt!server create`` には親チャンネルを1つだけ指定してください。例：``bt!server create art #art``",
+	"server.channels_required": "``bt!server %v`` にはチャンネルが1つ以上必要です",
+	"server.unknown_action":    "不明な操作 ``%v`` です。詳しくは bt!help server をご覧ください",
+	"server.filter_usage":      "``bt!server filter`` には引数が3つ以上必要です。\n**使い方：** ``bt!server filter <グループ名> <チャンネル> <ルール> [値]``",
+	"server.not_found":         "サーバーのクロスポストグループ **%v** が見つかりません",
+	"server.set_usage":         "``bt!server set`` には引数が3つ必要です。\n**使い方：** ``bt!server set <グループ名> <設定> <値>``",
+	"server.foreign_channel":   "チャンネル <#%v> は別のサーバーのものです。サーバーグループではこのサーバーのチャンネルのみ使用できます",
+
+	"group.name":                   "名前",
+	"group.group_name":             "グループ名",
+	"group.parent":                 "親",
+	"group.parent_channel":         "親チャンネル",
+	"group.channels":               "チャンネル",
+	"group.status":                 "状態",
+	"group.mode":                   "モード",
+	"group.all":                    "すべてのグループ",
+	"group.no_channels":            "有効なチャンネルが見つかりませんでした",
+	"group.no_groups":              "クロスポストグループはまだありません。",
+	"group.not_found":              "クロスポストグループ **%v** が見つかりません。",
+	"group.no_user":                "ユーザー設定が見つかりません。まず ``bt!create <グループ名> <親ID>`` でグループを作成してください",
+	"group.title":                  "%v さんのクロスポストグループ",
+	"group.empty":                  ":gun:🤠 *この町は二人には狭すぎるぜ！*\n",
+	"group.description":            "**親：** [<#%v>]\n**子：** %v",
+	"group.status_disabled":        "**状態：** 無効",
+	"group.status_paused":          "**状態：** %v まで一時停止",
+	"group.sources":                "**他のソース：** %v",
+	"group.mesh":                   "**メッシュ：** オン",
+	"group.webhook":                "**Webhook：** オン",
+	"group.no_attachments":         "**添付ファイル：** オフ",
+	"group.unknown_channel":        "チャンネル ``%v`` が見つかりません。Boe Teaがサーバーにいてチャンネルを読めることを確認してください",
+	"group.skipped_channel":        "``%v``：チャンネルが見つかりません。Boe Teaがサーバーにいてチャンネルを読めることを確認してください",
+	"group.create_usage":           "``bt!create`` には引数が2つ必要です。例：``bt!create touhou #lewdtouhouart``",
+	"group.created":                "✅ クロスポストグループを作成しました！",
+	"group.delete_usage":           "``bt!delete`` には引数が1つ以上必要です。\n**使い方：** ``bt!delete ntr``",
+	"group.delete_failed":          "❎ クロスポストグループを削除できませんでした！",
+	"group.deleted":                "✅ クロスポストグループを削除しました！",
+	"group.remove_usage":           "``bt!remove`` には引数が2つ以上必要です。\n**使い方：** ``bt!remove nudes #nsfw``",
+	"group.remove_failed":          "❎ クロスポストグループから削除できませんでした！",
+	"group.removed":                "✅ クロスポストグループからチャンネルを削除しました！",
+	"group.remove_channels_failed": "❎ クロスポストグループからチャンネルを削除できませんでした！",
+	"group.add_usage":              "``bt!push`` には引数が2つ以上必要です。\n**使い方：** ``bt!push hololive #marine-booty``",
+	"group.add_failed":             "❎ クロスポストグループに追加できませんでした！",
+	"group.already_member":         "チャンネル <#%v> はすでにグループ %v に含まれています",
+	"group.added":                  "✅ クロスポストグループにチャンネルを追加しました！",
+	"group.add_channels_failed":    "❎ クロスポストグループにチャンネルを追加できませんでした！",
+	"group.skipped_channels":       "スキップされたチャンネル",
+	"group.copy_usage":             "``bt!copy`` には引数が3つ以上必要です。\n**使い方：** ``bt!copy <コピー元> <コピー先> <新しい親チャンネル>``",
+	"group.copy_failed":            "❎ クロスポストグループをコピーできませんでした！",
+	"group.no_source":              "コピー元のグループ ``%v`` が見つかりません",
+	"group.name_taken":             "グループ名 %v はすでに使われています",
+	"group.copied":                 "✅ クロスポストグループをコピーしました！",
+	"group.set_usage":              "``bt!groupset`` には引数が3つ必要です。\n**使い方：** ``bt!groupset <グループ名> <設定> <値>``",
+	"group.unknown_setting":        "不明なグループ設定 ``%v`` です。詳しくは bt!help groupset をご覧ください",
+	"group.setting_changed":        "✅ グループの設定を変更しました！",
+	"group.nothing_to_export":      "エクスポートするクロスポストグループがありません",
+	"group.exported":               "%v 個のクロスポストグループをエクスポートしました。このファイルを添付して ``bt!crosspost import`` を使うと復元できます。",
+	"group.bad_import_mode":        "不明なインポートモード ``%v`` です。``merge`` または ``replace`` を使用してください",
+	"group.import_required":        "``bt!crosspost export`` で作成したJSONファイルを添付してください",
+	"group.import_too_large":       "ファイルが大きすぎます。最大サイズは %v KB です",
+	"group.import_download":        "ファイルをダウンロードできませんでした：%v",
+	"group.import_read":            "クロスポストグループを読み込めませんでした：%v",
+	"group.unreachable_channel":    "``%v``：チャンネルが見つかりません",
+	"group.duplicate_name":         "%v：グループ名が重複しています",
+	"group.unreachable_parent":     "%v：親チャンネルにアクセスできません",
+	"group.imported":               "✅ クロスポストグループをインポートしました！",
+	"group.imported_count":         "インポート数",
+	"group.groups":                 "グループ数",
+	"group.unreachable":            "アクセスできないチャンネル",
+	"group.skipped_groups":         "スキップされたグループ",
+	"group.bad_duration":           "期間 ``%v`` を解析できません。有効な期間の例：``30m``, ``2h``, ``1d``",
+	"group.pause_usage":            "``bt!pause`` には期間が必要です。\n**使い方：** ``bt!pause <期間> [グループ名]``",
+	"group.paused_until":           "%v まで一時停止",
+	"group.toggled_all":            "✅ クロスポストを切り替えました！",
+	"group.paused_all":             "✅ クロスポストを一時停止しました！",
+	"group.resumed_all":            "✅ クロスポストを再開しました！",
+	"group.toggled":                "✅ クロスポストグループを切り替えました！",
+	"group.paused":                 "✅ クロスポストグループを一時停止しました！",
+	"group.resumed":                "✅ クロスポストグループを再開しました！",
+	"group.source_usage":           "``bt!source`` には引数が3つ以上必要です。\n**使い方：** ``bt!source <グループ名> <add | remove> [チャンネル]``",
+	"group.bad_source_action":      "不明な操作 ``%v`` です。``add`` または ``remove`` を使用してください",
+	"group.sources_failed":         "❎ ソースチャンネルを編集できませんでした！",
+	"group.sources_edited":         "✅ ソースチャンネルを編集しました！",
+	"filter.usage":                 "``bt!filter`` には引数が3つ以上必要です。\n**使い方：** ``bt!filter <グループ名> <チャンネル> <ルール> [値]``",
+	"filter.not_destination":       "チャンネル <#%v> はグループ %v のクロスポストを受け取りません",
+	"filter.rating_required":       "rating には値が必要です：``sfw``, ``nsfw``, ``any``",
+	"filter.bad_rating":            "不明なレーティング ``%v`` です。``sfw``, ``nsfw``, ``any`` を使用してください",
+	"filter.bad_provider":          "不明なプロバイダー ``%v`` です。``pixiv``, ``twitter``, ``attachments``, ``any`` を使用してください",
+	"filter.bad_rule":              "不明なルール ``%v`` です。詳しくは bt!help filter をご覧ください",
+	"filter.changed":               "✅ クロスポストのフィルターを変更しました！",
+	"filter.filter":                "フィルター",
+	"filter.tags":                  "**タグ：** %v",
+	"filter.excluded":              "**除外タグ：** %v",
+	"filter.rating":                "**レーティング：** %v",
+	"filter.providers":             "**プロバイダー：** %v",
+	"filter.likes":                 "**いいね：** %v+",
+
+	"settings.changed":   "✅ 設定を変更しました！",
+	"settings.setting":   "設定",
+	"settings.new_value": "新しい値",
+	"settings.bad_usage": "コマンドの使い方が正しくありません。詳しくは bt!help set をご覧ください",
+	"settings.bad_name":  "無効な設定名です：%v",
+
+	"settings.channel_changed": "✅ チャンネルの設定を変更しました！",
+	"settings.channel":         "チャンネル",
+	"settings.inherited":       "サーバーの設定を継承",
+	"settings.channel_title":   "現在のチャンネル設定",
+	"settings.overrides":       "上書き",
+	"settings.effective":       "有効な設定",
+	"settings.bad_channel":     "このサーバーにチャンネル ``%v`` が見つかりません",
+	"settings.not_per_channel": "設定 %v はチャンネルごとに変更できません。利用可能な設定：pixiv, twitter, twitterprompt, limit, repost, nsfw",
+	"settings.bad_largeset":    "アルバムのサイズに負の値は使えません。0 または off で無効にできます",
+	"settings.bad_footer":      "不明なオプションです。footer には default, custom, mixed, off のみ使用できます",
+	"settings.foreign_emoji":   "このサーバーの絵文字ではありません。Unicode絵文字またはこのサーバーの絵文字のみ使用できます",
+	"settings.not_emoji":       "%v は絵文字ではありません。Unicode絵文字またはこのサーバーの絵文字のみ使用できます",
+	"managers.title":           "ボット管理者",
+	"managers.roles":           "ロール",
+	"managers.changed":         "✅ ボット管理者を変更しました！",
+	"managers.admins_only":     "管理者のみ",
+	"managers.bad_role":        "このサーバーにロール ``%v`` が見つかりません",
+	"history.title":            "設定の履歴",
+	"history.empty":            "設定はまだ変更されていません。",
+	"history.entry":            "**#%v** ``%v``（<@%v>、%v）\n%v → %v",
+	"history.footer":           "%v/%vページ。``bt!set rollback <番号>`` で変更前の値に戻せます。",
+	"history.entry_required":   "履歴の番号が必要です。bt!set history で確認できます",
+	"history.not_found":        "履歴 #%v は存在しません",
+	"history.rolled_back":      "✅ 設定を元に戻しました！",
+	"history.restored":         "復元した値",
+	"footers.title":            "フッターメッセージ",
+	"footers.empty":            "このサーバーにはフッターメッセージがありません。ボット管理者は ``bt!set footers add [--nsfw] <メッセージ>`` で追加できます",
+	"footers.mode":             "モード",
+	"footers.page":             "%v/%vページ",
+	"footers.added":            "✅ フッターメッセージを追加しました！",
+	"footers.removed":          "✅ フッターメッセージを削除しました！",
+	"footers.required":         "フッターメッセージが必要です",
+	"footers.too_long":         "フッターメッセージは256文字以内にしてください",
+	"embed.title":              "埋め込みテンプレート",
+	"embed.kind_title":         "%v の埋め込みテンプレート",
+	"embed.template":           "テンプレート",
+	"embed.fields":             "フィールド",
+	"embed.embed":              "埋め込み",
+	"embed.changed":            "✅ 埋め込みテンプレートを変更しました！",
+	"embed.unknown":            "不明な埋め込み ``%v`` です。利用可能な埋め込み：pixiv, twitter, repost, sauce",
+	"embed.bad_color":          "``%v`` は有効な16進数の色ではありません。例：#439ef1",
+	"embed.bad_url":            "``%v`` は画像のURLではありません",
+	"embed.unknown_field":      "不明なフィールド ``%v`` です。利用可能なフィールド：%v",
+	"embed.bad_tags":           "タグの数は0以上の数値または ``all`` にしてください",
+	"embed.footer_too_long":    "フッターのテキストは2048文字以内にしてください",
+	"embed.unknown_property":   "不明なプロパティ ``%v`` です。利用可能なプロパティ：color, thumbnail, fields, tags, footer, reset",
+
+	"settings.title":                "現在の設定",
+	"settings.general":              "一般",
+	"settings.general_value":        "**プレフィックス：** %v | **NSFW：** %v",
+	"settings.features":             "機能",
+	"settings.features_value":       "**リポスト：** %v | **クロスポスト：** %v | **逆画像検索：** %v | **確認の絵文字：** %v | **フッター：** %v | **言語：** %v",
+	"settings.pixiv":                "Pixivの設定",
+	"settings.pixiv_value":          "**自動リポスト（pixiv）：** %v | **上限：** %v | **大きなアルバム：** %v | **うごイラ：** %v",
+	"settings.twitter":              "Twitterの設定",
+	"settings.twitter_value":        "**自動リポスト（twitter）：** %v | **確認：** %v",
+	"settings.channel_overrides":    "チャンネルの上書き",
+	"settings.no_channel_overrides": "なし。``bt!set --channel <チャンネル> <設定> <値>`` で追加できます。",
+	"settings.no_overrides":         "上書きなし",
+	"settings.effective_value":      "**Pixiv：** %v | **Twitter：** %v | **確認：** %v | **上限：** %v | **リポスト：** %v | **NSFW：** %v",
+	"settings.enabled":              "有効",
+	"settings.disabled":             "無効",
+	"settings.unset":                "*未設定*",
+	"settings.long_prefix":          "新しいプレフィックス（%v）が長すぎます（%v）。最大の長さは %v です",
+	"settings.bad_reverse_search":   "不明なオプションです。reversesearch には saucenao と wait のみ使用できます",
+	"settings.bad_repost":           "不明なオプションです。repost には enabled, disabled, strict のみ使用できます",
+	"embed.default":                 "デフォルト",
+	"embed.default_templates":       "デフォルト。``bt!set embed`` で埋め込みをカスタマイズできます。",
+	"embed.customised":              "カスタマイズ済み：%v",
+	"consent.title":                 "警告！",
+	"consent.description":           "リポストのチェックには以下のデータの収集が必要です。この情報の共有に同意しますか？",
+	"consent.content":               "投稿の内容",
+	"consent.content_value":         "PixivのIDまたはTwitterのリンク。リポストのチェックに当然必要です",
+	"consent.date":                  "投稿日時",
+	"consent.date_value":            "24時間後にデータベースから削除するために必要です",
+	"consent.username":              "投稿者のユーザー名（IDやタグは含みません）",
+	"consent.username_value":        "リポストが検出されたときに元の投稿者の情報を示すために必要です",
+	"consent.ids":                   "サーバーID、メッセージID、チャンネルID",
+	"consent.ids_value":             "リポストのチェックに必要です。データベースでリポストを探し、元の投稿へのリンクを作成するために使われます。",
+	"consent.cancelled":             "リポストチェッカーの有効化をキャンセルしました。このエラーは無視してください",
+
+	"locale.title":           "言語",
+	"locale.changed":         "✅ 言語を変更しました！",
+	"locale.current":         "現在の言語",
+	"locale.available":       "利用可能な言語",
+	"locale.server":          "サーバーの言語",
+	"locale.unsupported":     "``%v`` には対応していません。利用可能な言語：%v",
+	"locale.name":            "日本語",
+	"help.set.usage.name":    "使い方",
+	"help.set.usage":         "bt!set ``<設定>`` ``<新しい値>``",
+	"help.set.managers.name": "ボット管理者",
+	"help.set.managers":      "bt!set managers ``[<add | remove> <ロール>]``。これらのロールを持つメンバーは設定とサーバーのクロスポストグループを変更できます。リストを変更できるのは管理者のみです。",
+	"help.set.embed.name":    "埋め込みテンプレート",
+	"help.set.embed":         "bt!set embed ``[<pixiv | twitter | repost | sauce> <プロパティ> <値>]``。プロパティ：***color***（16進数またはdefault）、***thumbnail***（画像URL、noneまたはdefault）、***fields***（表示するフィールド、allまたはnone）、***tags***（タグの数またはall）、***footer***（テキスト、noneまたはdefault）、***reset***。",
+	"help.set.footers.name":  "フッターメッセージ",
+	"help.set.footers":       "bt!set footers ``[add [--nsfw] <メッセージ> | remove <番号>]``。サーバー独自の埋め込みフッターを管理します。***NSFW***のメッセージはNSFWではないチャンネルでは使われません。",
+	"help.set.history.name":  "履歴",
+	"help.set.history":       "``bt!set history`` で最近の設定変更を表示し、``bt!set rollback <番号>`` で変更前の値に戻します。クロスポストグループなどのリストは全体が元に戻ります。ボット管理者ロールが必要です。",
+	"help.set.channel.name":  "チャンネル別設定",
+	"help.set.channel":       "bt!set --channel ``<チャンネル>`` ``[<設定> <新しい値>]``。1つのチャンネルで ***pixiv, twitter, twitterprompt, limit, repost, nsfw*** を上書きします。***inherit*** で上書きを解除します。設定を省略するとチャンネルの設定を表示します。",
+	"help.set.prefix":        "ボットのプレフィックス。***5文字***まで。最後の文字が英字の場合は空白が付くものとみなされます（1文字分）。",
+	"help.set.footer":        "埋め込みフッターの出典。有効な値：***[default, custom, mixed, off]***。***custom*** はサーバー独自のメッセージのみ、***mixed*** はボットのメッセージに追加して使います。",
+	"help.set.largeset":      "大きいと見なされ、投稿時に確認が表示されるアルバムのサイズ。***0*** または ***off*** で確認を無効にします。",
+	"help.set.limit":         "アルバムのサイズの上限。上限を超えるとアルバムの最初の画像のみ投稿されます。",
+	"help.set.pixiv":         "PixivまたはTwitterのリポストのオン・オフ。有効な値：***[enabled, on, t, true], [disabled, off, f, false]***",
+	"help.set.repost":        "リポストの確認設定。有効な値：***[enabled, disabled, strict]***。strictモードでは確認なしでリポストを削除します。",
+	"help.set.ugoira":        "Pixivのうごイラの形式。有効な値：***[mp4, gif, webm, apng]***。ファイルが大きすぎる場合は小さい形式や解像度に切り替えます。",
+	"help.set.reversesearch": "デフォルトの画像検索エンジン。有効な値：***[saucenao, wait]***",
+	"help.set.promptemoji":   "確認プロンプトの絵文字。Unicode絵文字またはこのサーバーの絵文字のみ使用できます。",
+	"help.set.locale":        "サーバーの言語。有効な値：***[en, ja]***。メンバーは ``bt!locale`` で自分の言語を選べます。",
+}
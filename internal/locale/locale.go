@@ -0,0 +1,83 @@
+package locale
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//Default is a locale of last resort. Its catalogue is expected to have every message.
+const Default = "en"
+
+//catalogues maps locale codes to their messages.
+var catalogues = map[string]map[string]string{
+	"en": en,
+	"ja": ja,
+}
+
+//Supported returns codes of bundled locales.
+func Supported() []string {
+	codes := make([]string, 0, len(catalogues))
+	for code := range catalogues {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+//IsSupported reports whether a locale or its base language is bundled, e.g. ja-JP is supported by ja.
+func IsSupported(code string) bool {
+	for _, c := range expand(code) {
+		if _, ok := catalogues[c]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+//Get returns a message from the first locale of a chain that has it, formatted with args.
+//Regional locales fall back to their base language and every chain ends with the default locale.
+//A key itself is returned if no catalogue has a message.
+func Get(chain []string, key string, args ...interface{}) string {
+	for _, code := range Chain(chain...) {
+		if msg, ok := catalogues[code][key]; ok {
+			if len(args) == 0 {
+				return msg
+			}
+			return fmt.Sprintf(msg, args...)
+		}
+	}
+
+	return key
+}
+
+//Chain builds a fallback chain from preferred locales. Empty and repeated locales are skipped.
+func Chain(preferred ...string) []string {
+	var (
+		chain = make([]string, 0, len(preferred)+1)
+		seen  = make(map[string]bool)
+	)
+
+	for _, code := range append(preferred, Default) {
+		for _, c := range expand(code) {
+			if c != "" && !seen[c] {
+				seen[c] = true
+				chain = append(chain, c)
+			}
+		}
+	}
+
+	return chain
+}
+
+//expand returns a normalised locale code followed by its base language.
+func expand(code string) []string {
+	code = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(code)), "_", "-")
+	if ind := strings.IndexByte(code, '-'); ind != -1 {
+		return []string{code, code[:ind]}
+	}
+
+	return []string{code}
+}
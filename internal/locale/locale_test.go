@@ -0,0 +1,77 @@
+package locale
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred []string
+		want      []string
+	}{
+		{"empty", nil, []string{"en"}},
+		{"single", []string{"ja"}, []string{"ja", "en"}},
+		{"regional", []string{"ja_JP"}, []string{"ja-jp", "ja", "en"}},
+		{"repeated", []string{"ja", "", "JA", "en"}, []string{"ja", "en"}},
+		{"user and guild", []string{"en-GB", "ja"}, []string{"en-gb", "en", "ja"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Chain(tt.preferred...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain(%v) = %v, want %v", tt.preferred, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	//a partial catalogue to test fallback without relying on gaps in bundled ones
+	catalogues["test"] = map[string]string{"prompt.confirm": "OK"}
+	defer delete(catalogues, "test")
+
+	tests := []struct {
+		name  string
+		chain []string
+		key   string
+		args  []interface{}
+		want  string
+	}{
+		{"default", nil, "prompt.confirm", nil, "Confirm"},
+		{"japanese", []string{"ja"}, "prompt.confirm", nil, "確認"},
+		{"regional falls back to base", []string{"ja-JP"}, "prompt.confirm", nil, "確認"},
+		{"unknown locale", []string{"xx", "ja"}, "prompt.confirm", nil, "確認"},
+		{"missing message falls back to english", []string{"test"}, "prompt.cancel", nil, "Cancel"},
+		{"message of a test locale", []string{"test"}, "prompt.confirm", nil, "OK"},
+		{"missing everywhere", []string{"ja"}, "no.such.key", nil, "no.such.key"},
+		{"formatted", []string{"en"}, "repost.page", []interface{}{3}, "Page 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Get(tt.chain, tt.key, tt.args...); got != tt.want {
+				t.Errorf("Get(%v, %v) = %v, want %v", tt.chain, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	for code, want := range map[string]bool{"en": true, "ja": true, "ja-JP": true, "JA": true, "de": false, "": false} {
+		if got := IsSupported(code); got != want {
+			t.Errorf("IsSupported(%q) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+func TestCatalogues(t *testing.T) {
+	for code, catalogue := range catalogues {
+		for key := range catalogue {
+			if _, ok := catalogues[Default][key]; !ok {
+				t.Errorf("message %v of locale %v is missing in the default locale", key, code)
+			}
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package prompt
+
+import (
+	"strings"
+	"time"
+
+	"github.com/VTGare/boe-tea-go/internal/dispatcher"
+	"github.com/VTGare/boe-tea-go/internal/locale"
+	"github.com/VTGare/boe-tea-go/utils"
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+//Options is a struct that defines prompt's behaviour.
+type Options struct {
+	Actions map[string]bool
+	Message string
+	Timeout time.Duration
+}
+
+const (
+	promptConfirm = "prompt:confirm:"
+	promptCancel  = "prompt:cancel:"
+)
+
+//Create sends a prompt message to a discord channel
+func Create(s *discordgo.Session, m *discordgo.MessageCreate, opts *Options) bool {
+	prompt, _ := create(s, m, &discordgo.MessageSend{Content: opts.Message}, opts.Actions, opts.Timeout)
+	return prompt
+}
+
+//CreateWithMessage sends a prompt message to a discord channel. Emoji is a confirmation emoji of the server.
+func CreateWithMessage(s *discordgo.Session, m *discordgo.MessageCreate, message *discordgo.MessageSend, emoji string) bool {
+	var (
+		timeout = 45 * time.Second
+		actions = map[string]bool{emoji: true, "🙅‍♂️": false}
+	)
+
+	prompt, err := create(s, m, message, actions, timeout)
+	if err != nil {
+		s.ChannelMessageSend(m.ChannelID, locale.Get(dispatcher.Default.UserLocales(m.GuildID, m.Author.ID), "prompt.failed"))
+	}
+
+	return prompt
+}
+
+//create asks a message author to press confirm or cancel button.
+//Reactions are used if a message with buttons couldn't be sent.
+func create(s *discordgo.Session, m *discordgo.MessageCreate, message *discordgo.MessageSend, actions map[string]bool, timeout time.Duration) (bool, error) {
+	//subscription comes first, a click can arrive before the message is returned
+	nonce := dispatcher.NewNonce()
+	components, cancel := dispatcher.Default.ComponentsByNonce(nonce)
+	defer cancel()
+
+	send := *message
+	send.Components = Buttons(actions, dispatcher.Default.UserLocales(m.GuildID, m.Author.ID), nonce)
+
+	prompt, err := s.ChannelMessageSendComplex(m.ChannelID, &send)
+	if err != nil {
+		log.Warnf("create(): %v. Falling back to reactions.", err)
+		return reactionPrompt(s, m, message, actions, timeout)
+	}
+	defer s.ChannelMessageDelete(prompt.ChannelID, prompt.ID)
+
+	i, ok := AwaitComponent(s, components, m.Author.ID, timeout)
+	if !ok {
+		return false, nil
+	}
+
+	AcknowledgeComponent(s, i)
+	return strings.HasPrefix(dispatcher.StripNonce(i.MessageComponentData().CustomID), promptConfirm), nil
+}
+
+//reactionPrompt is a prompt driven by emoji reactions.
+func reactionPrompt(s *discordgo.Session, m *discordgo.MessageCreate, message *discordgo.MessageSend, actions map[string]bool, timeout time.Duration) (bool, error) {
+	prompt, err := s.ChannelMessageSendComplex(m.ChannelID, message)
+	if err != nil || prompt == nil {
+		log.Warnln(err)
+		return false, err
+	}
+
+	reactions, cancel := dispatcher.Default.Reactions(prompt.ID)
+	defer cancel()
+
+	for emoji := range actions {
+		err = s.MessageReactionAdd(m.ChannelID, prompt.ID, emoji)
+		if err != nil {
+			log.Warnln(err)
+			return false, err
+		}
+	}
+
+	var (
+		reaction *discordgo.MessageReaction
+		deadline = time.After(timeout)
+	)
+	for {
+		select {
+		case k := <-reactions:
+			reaction = k.MessageReaction
+		case <-deadline:
+			s.ChannelMessageDelete(prompt.ChannelID, prompt.ID)
+			return false, nil
+		}
+
+		if _, ok := actions[reaction.Emoji.APIName()]; !ok {
+			continue
+		}
+
+		if s.State.User.ID == reaction.UserID || reaction.UserID != m.Author.ID {
+			continue
+		}
+
+		s.ChannelMessageDelete(prompt.ChannelID, prompt.ID)
+		return actions[reaction.Emoji.APIName()], nil
+	}
+}
+
+//ComponentEmoji converts an emoji API name to a component emoji. Custom emojis are formatted as name:ID.
+func ComponentEmoji(emoji string) discordgo.ComponentEmoji {
+	if ind := strings.LastIndex(emoji, ":"); ind != -1 {
+		return discordgo.ComponentEmoji{Name: strings.TrimPrefix(emoji[:ind], "a:"), ID: emoji[ind+1:]}
+	}
+
+	return discordgo.ComponentEmoji{Name: emoji}
+}
+
+//Buttons creates confirm and cancel buttons out of prompt actions. Cancel button is added if actions don't have one.
+//Labels are translated to the first available of given locales, custom IDs carry a nonce.
+func Buttons(actions map[string]bool, locales []string, nonce string) []discordgo.MessageComponent {
+	var (
+		confirm = make([]discordgo.MessageComponent, 0)
+		cancel  = make([]discordgo.MessageComponent, 0)
+		yes     = locale.Get(locales, "prompt.confirm")
+		no      = locale.Get(locales, "prompt.cancel")
+	)
+
+	for emoji, ok := range actions {
+		if ok {
+			confirm = append(confirm, discordgo.Button{Label: yes, Style: discordgo.SuccessButton, Emoji: ComponentEmoji(emoji), CustomID: dispatcher.WithNonce(nonce, promptConfirm+emoji)})
+		} else {
+			cancel = append(cancel, discordgo.Button{Label: no, Style: discordgo.DangerButton, Emoji: ComponentEmoji(emoji), CustomID: dispatcher.WithNonce(nonce, promptCancel+emoji)})
+		}
+	}
+
+	if len(cancel) == 0 {
+		cancel = append(cancel, discordgo.Button{Label: no, Style: discordgo.SecondaryButton, CustomID: dispatcher.WithNonce(nonce, promptCancel)})
+	}
+
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: append(confirm, cancel...)}}
+}
+
+//CreateSelect asks a message author to pick up to maxValues options from a select menu.
+//Nil is returned if the prompt was cancelled, timed out or couldn't be sent.
+func CreateSelect(s *discordgo.Session, m *discordgo.MessageCreate, message *discordgo.MessageSend, options []discordgo.SelectMenuOption, maxValues int, timeout time.Duration) []string {
+	var (
+		locales = dispatcher.Default.UserLocales(m.GuildID, m.Author.ID)
+		nonce   = dispatcher.NewNonce()
+	)
+
+	components, cancel := dispatcher.Default.ComponentsByNonce(nonce)
+	defer cancel()
+
+	send := *message
+	send.Components = []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.SelectMenu{CustomID: dispatcher.WithNonce(nonce, "prompt:select"), Placeholder: locale.Get(locales, "prompt.pick"), MaxValues: utils.Min(maxValues, len(options)), Options: options},
+		}},
+		discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.Button{Label: locale.Get(locales, "prompt.cancel"), Style: discordgo.SecondaryButton, CustomID: dispatcher.WithNonce(nonce, promptCancel)},
+		}},
+	}
+
+	prompt, err := s.ChannelMessageSendComplex(m.ChannelID, &send)
+	if err != nil {
+		log.Warnf("CreateSelect(): %v", err)
+		return nil
+	}
+	defer s.ChannelMessageDelete(prompt.ChannelID, prompt.ID)
+
+	i, ok := AwaitComponent(s, components, m.Author.ID, timeout)
+	if !ok {
+		return nil
+	}
+
+	AcknowledgeComponent(s, i)
+	return i.MessageComponentData().Values
+}
+
+//AwaitComponent waits until a user interacts with subscribed components. Other users are told the message isn't meant for them.
+//Subscription is made by a caller with dispatcher's ComponentsByNonce before sending a message.
+func AwaitComponent(s *discordgo.Session, components <-chan *discordgo.InteractionCreate, userID string, timeout time.Duration) (*discordgo.InteractionCreate, bool) {
+	deadline := time.After(timeout)
+	for {
+		var i *discordgo.InteractionCreate
+		select {
+		case i = <-components:
+		case <-deadline:
+			return nil, false
+		}
+
+		user := i.User
+		if i.Member != nil {
+			user = i.Member.User
+		}
+
+		if user == nil || user.ID != userID {
+			var locales []string
+			if user != nil {
+				locales = dispatcher.Default.UserLocales(i.GuildID, user.ID)
+			}
+
+			err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{Content: locale.Get(locales, "prompt.not_yours"), Flags: discordgo.MessageFlagsEphemeral},
+			})
+			if err != nil {
+				log.Warnln(err)
+			}
+			continue
+		}
+
+		return i, true
+	}
+}
+
+//AcknowledgeComponent acknowledges a component interaction without changing its message.
+func AcknowledgeComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{Type: discordgo.InteractionResponseDeferredMessageUpdate})
+	if err != nil {
+		log.Warnln(err)
+	}
+}
@@ -0,0 +1,179 @@
+package repost
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/VTGare/boe-tea-go/internal/database"
+	"github.com/VTGare/boe-tea-go/internal/ugoira"
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+var imageExtensions = map[string]bool{
+	".png":  true,
+	".jpg":  true,
+	".jpeg": true,
+	".gif":  true,
+	".webp": true,
+}
+
+var (
+	//attachmentClient downloads attachments. A stalled response can't block a crosspost for longer than its timeout.
+	attachmentClient  = &http.Client{Timeout: time.Minute}
+	errAttachmentSize = errors.New("attachment is larger than upload limit")
+)
+
+//attachment is a downloaded image attachment of an original message.
+type attachment struct {
+	name string
+	data []byte
+}
+
+//imageAttachments returns image attachments of a message.
+func imageAttachments(m *discordgo.Message) []*discordgo.MessageAttachment {
+	images := make([]*discordgo.MessageAttachment, 0)
+	for _, att := range m.Attachments {
+		if imageExtensions[strings.ToLower(filepath.Ext(att.Filename))] {
+			images = append(images, att)
+		}
+	}
+
+	return images
+}
+
+//downloadAttachments downloads image attachments once per post. Attachments larger than upload limit are skipped.
+func (a *ArtPost) downloadAttachments() {
+	if a.files != nil {
+		return
+	}
+
+	a.files = make([]*attachment, 0, len(a.Attachments))
+	for _, att := range a.Attachments {
+		var (
+			data []byte
+			err  = errAttachmentSize
+		)
+
+		if int64(att.Size) <= ugoira.UploadLimit {
+			data, err = downloadAttachment(att.URL)
+		}
+
+		switch {
+		case err == errAttachmentSize:
+			a.filesSkipped = append(a.filesSkipped, fmt.Sprintf("``%v``: attachment is larger than %v MB.", att.Filename, ugoira.UploadLimit/1024/1024))
+		case err != nil:
+			logrus.Warnf("downloadAttachments(): %v", err)
+		default:
+			a.files = append(a.files, &attachment{att.Filename, data})
+		}
+	}
+}
+
+//downloadAttachment downloads a file up to upload limit. Reported size of an attachment isn't trusted, reading stops past the limit.
+func downloadAttachment(url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), attachmentClient.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := attachmentClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %v", resp.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, ugoira.UploadLimit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > ugoira.UploadLimit {
+		return nil, errAttachmentSize
+	}
+
+	return data, nil
+}
+
+//sendAttachments re-uploads image attachments of an original message with its caption.
+//Files are split into several messages if they don't fit into upload limit together.
+func (a *ArtPost) sendAttachments(s *discordgo.Session, m *discordgo.MessageCreate) {
+	a.downloadAttachments()
+	if len(a.files) == 0 {
+		return
+	}
+
+	caption := a.t("crosspost.requested_by", a.event.Author.String())
+	if content := strings.TrimSpace(a.event.Content); content != "" {
+		caption += "\n" + content
+	}
+	if runes := []rune(caption); len(runes) > 2000 {
+		caption = string(runes[:1997]) + "..."
+	}
+
+	var (
+		batches = make([][]*attachment, 0)
+		batch   = make([]*attachment, 0)
+		size    int64
+	)
+
+	for _, f := range a.files {
+		if len(batch) > 0 && (size+int64(len(f.data)) > ugoira.UploadLimit || len(batch) == 10) {
+			batches = append(batches, batch)
+			batch, size = make([]*attachment, 0), 0
+		}
+
+		batch = append(batch, f)
+		size += int64(len(f.data))
+	}
+	batches = append(batches, batch)
+
+	for ind, batch := range batches {
+		send := &discordgo.MessageSend{
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		}
+
+		if ind == 0 {
+			send.Content = caption
+		}
+
+		for _, f := range batch {
+			send.Files = append(send.Files, &discordgo.File{Name: f.name, Reader: bytes.NewReader(f.data)})
+		}
+
+		a.send(s, m, send)
+	}
+}
+
+//allowsAttachments reports whether image attachments can be cross-posted to a target.
+//Attachments have no rating of their own, they're considered NSFW if the origin channel is NSFW.
+//A reason is returned if attachments are rejected by server settings rather than by target's filters.
+func (a *ArtPost) allowsAttachments(guild *database.GuildSettings, ch *discordgo.Channel, target *database.Target, nsfw bool) (bool, string) {
+	switch {
+	case !target.Attachments || len(a.Attachments) == 0:
+		return false, ""
+	case guild == nil || !guild.Crosspost:
+		return false, a.t("crosspost.disabled")
+	case nsfw && !guild.NSFW:
+		return false, a.t("nsfw.server")
+	case nsfw && !ch.NSFW:
+		return false, a.t("nsfw.channel")
+	}
+
+	return target.Allows("attachments", nil, nsfw, 0), ""
+}
@@ -0,0 +1,103 @@
+package repost
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ReneKroon/ttlcache"
+	"github.com/VTGare/boe-tea-go/utils"
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	//families maps IDs of original messages to embeds sent in response to them in every channel.
+	families   *ttlcache.Cache
+	familiesMu sync.Mutex
+
+	//delivered remembers recently cross-posted artworks per channel to break cross-posting loops.
+	delivered *ttlcache.Cache
+)
+
+func init() {
+	families = ttlcache.NewCache()
+	families.SetTTL(24 * time.Hour)
+	families.SkipTtlExtensionOnHit(true)
+
+	delivered = ttlcache.NewCache()
+	delivered.SetTTL(5 * time.Minute)
+	delivered.SkipTtlExtensionOnHit(true)
+}
+
+//markDelivered records that artworks have been posted in a channel.
+func markDelivered(channelID string, ids ...map[string]bool) {
+	for _, m := range ids {
+		for id := range m {
+			delivered.Set(channelID+id, true)
+		}
+	}
+}
+
+//dropDelivered removes artworks that have recently been posted or cross-posted to a channel.
+func dropDelivered(channelID string, ids ...map[string]bool) {
+	for _, m := range ids {
+		for id := range m {
+			if _, ok := delivered.Get(channelID + id); ok {
+				delete(m, id)
+			}
+		}
+	}
+}
+
+//trackFamily starts recording messages sent in response to an original message.
+//It must be called before crossposting modifies the event.
+func trackFamily(m *discordgo.MessageCreate) {
+	familiesMu.Lock()
+	defer familiesMu.Unlock()
+
+	if _, ok := families.Get(m.ID); ok {
+		return
+	}
+
+	parent := *m.Message
+	families.Set(m.ID, &utils.CachedMessage{Parent: &parent, Children: make([]*discordgo.Message, 0)})
+}
+
+//addChild records a message sent in response to an original message.
+func addChild(parentID string, msg *discordgo.Message) {
+	familiesMu.Lock()
+	defer familiesMu.Unlock()
+
+	if family, ok := families.Get(parentID); ok {
+		family := family.(*utils.CachedMessage)
+		family.Children = append(family.Children, msg)
+	}
+}
+
+//DeleteFamily deletes every message sent in response to an original message across all channels.
+//It reports whether the original message was known.
+func DeleteFamily(s *discordgo.Session, parentID string) bool {
+	familiesMu.Lock()
+	cached, ok := families.Get(parentID)
+	if ok {
+		families.Remove(parentID)
+	}
+	familiesMu.Unlock()
+
+	CancelRenders(parentID)
+	if !ok {
+		return false
+	}
+
+	family := cached.(*utils.CachedMessage)
+	for _, child := range family.Children {
+		MsgCache.Remove(child.ChannelID + child.ID)
+
+		err := s.ChannelMessageDelete(child.ChannelID, child.ID)
+		if err != nil {
+			logrus.Warnf("ChannelMessageDelete(): %v", err)
+		}
+	}
+
+	return true
+}
@@ -3,17 +3,16 @@ package repost
 import (
 	"errors"
 	"fmt"
-	"math/rand"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/VTGare/boe-tea-go/internal/database"
+	"github.com/VTGare/boe-tea-go/internal/prompt"
 	"github.com/VTGare/boe-tea-go/internal/ugoira"
 	"github.com/VTGare/boe-tea-go/utils"
 	"github.com/bwmarrin/discordgo"
-	"github.com/sirupsen/logrus"
 )
 
 func (a *ArtPost) fetchPixivPosts(IDs map[string]bool) ([]*ugoira.PixivPost, error) {
@@ -76,10 +75,11 @@ func (a *ArtPost) SendPixiv(s *discordgo.Session, IDs map[string]bool, opts ...S
 	}
 
 	var (
-		guild      = database.GuildCache[a.event.GuildID]
+		guild      = database.Settings(a.event.GuildID, a.event.ChannelID)
 		indexMap   = make(map[int]bool)
 		include    bool
 		skipUgoira bool
+		format     ugoira.Format
 		err        error
 	)
 
@@ -89,6 +89,14 @@ func (a *ArtPost) SendPixiv(s *discordgo.Session, IDs map[string]bool, opts ...S
 			include = opts[0].Include
 		}
 		skipUgoira = opts[0].SkipUgoira
+		format = opts[0].UgoiraFormat
+	}
+
+	if format == "" {
+		format, err = ugoira.ParseFormat(guild.UgoiraFormat)
+		if err != nil {
+			format = ugoira.FormatMP4
+		}
 	}
 
 	posts, err := a.fetchPixivPosts(IDs)
@@ -96,26 +104,21 @@ func (a *ArtPost) SendPixiv(s *discordgo.Session, IDs map[string]bool, opts ...S
 		return nil, nil, err
 	}
 
-	if len(opts) != 0 {
-		if opts[0].SkipUgoira {
-
-		}
-	}
-
 	for excl := range indexMap {
 		if excl < 0 || excl > countPages(posts) {
 			delete(indexMap, excl)
 		}
 	}
 
-	if isNSFW(posts) {
+	//Cross-posts are checked against destination's settings beforehand.
+	if isNSFW(posts) && !a.IsCrosspost {
 		if !guild.NSFW {
 			s.ChannelMessageSendEmbed(a.event.ChannelID, &discordgo.MessageEmbed{
-				Title:     "❎ Pixiv post has not been reposted.",
+				Title:     a.t("nsfw.title"),
 				Color:     utils.EmbedColor,
 				Thumbnail: &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
 				Timestamp: utils.EmbedTimestamp(),
-				Fields:    []*discordgo.MessageEmbedField{{"Reason", "An NSFW post has been detected. The server prohibits NSFW content.", false}},
+				Fields:    []*discordgo.MessageEmbedField{{Name: a.t("nsfw.reason"), Value: a.t("nsfw.server")}},
 			})
 
 			return nil, nil, nil
@@ -126,20 +129,50 @@ func (a *ArtPost) SendPixiv(s *discordgo.Session, IDs map[string]bool, opts ...S
 		}
 
 		if !ch.NSFW {
-			prompt := utils.CreatePrompt(s, a.event, &utils.PromptOptions{
+			confirmed := prompt.Create(s, a.event, &prompt.Options{
 				Actions: map[string]bool{
-					"👌": true,
+					guild.ConfirmEmoji(): true,
 				},
-				Message: fmt.Sprintf("You're trying to send an NSFW post in a SFW channel, are you sure about that?"),
+				Message: a.t("nsfw.prompt"),
 				Timeout: 15 * time.Second,
 			})
-			if !prompt {
+			if !confirmed {
 				return nil, nil, nil
 			}
 		}
 	}
 
-	return createPixivEmbeds(a, posts, indexMap, include, skipUgoira, guild), posts, nil
+	//Authors of large albums pick pages instead of getting only the first one.
+	if count := countPages(posts); !a.IsCrosspost && len(indexMap) == 0 && len(posts) == 1 && guild.Limit > 0 && count > guild.Limit && count <= 25 {
+		if picked := a.pickPages(s, posts[0], guild.Limit); len(picked) > 0 {
+			indexMap = picked
+			include = true
+			a.pagesPicked = true
+		}
+	}
+
+	return createPixivEmbeds(a, posts, indexMap, include, skipUgoira, format, guild), posts, nil
+}
+
+//pickPages asks an author to pick pages of an album that's larger than server's limit.
+func (a *ArtPost) pickPages(s *discordgo.Session, post *ugoira.PixivPost, limit int) map[int]bool {
+	options := make([]discordgo.SelectMenuOption, 0, post.Len())
+	for ind := 1; ind <= post.Len(); ind++ {
+		options = append(options, discordgo.SelectMenuOption{Label: a.t("repost.page", ind), Value: strconv.Itoa(ind)})
+	}
+
+	values := prompt.CreateSelect(s, a.event, &discordgo.MessageSend{
+		Content: a.t("repost.pick_pages", post.Len(), limit, limit),
+	}, options, limit, 20*time.Second)
+
+	picked := make(map[int]bool)
+	for _, v := range values {
+		if ind, err := strconv.Atoi(v); err == nil {
+			picked[ind] = true
+		}
+	}
+
+	return picked
 }
 
 func joinTags(elems []string, sep string) string {
@@ -164,21 +197,21 @@ func joinTags(elems []string, sep string) string {
 	return b.String()
 }
 
-func createPixivEmbeds(a *ArtPost, posts []*ugoira.PixivPost, indexMap map[int]bool, include, skipUgoira bool, guild *database.GuildSettings) []*discordgo.MessageSend {
+func createPixivEmbeds(a *ArtPost, posts []*ugoira.PixivPost, indexMap map[int]bool, include, skipUgoira bool, format ugoira.Format, guild *database.GuildSettings) []*discordgo.MessageSend {
 	var (
 		easterEgg    *embedMessage
 		createdCount = 0
 		messages     = make([]*discordgo.MessageSend, 0)
 	)
 
-	g := database.GuildCache[a.event.GuildID]
-	if !g.NSFW {
-		easterEgg = sfwEmbedMessages[rand.Intn(len(sfwEmbedMessages))]
-	} else {
-		easterEgg = embedMessages[rand.Intn(len(embedMessages))]
-	}
+	g := database.Settings(a.event.GuildID, a.event.ChannelID)
+	easterEgg = randomFooter(g)
+	template := g.Template(database.PixivEmbed)
 
 	count := countPages(posts) - len(indexMap)
+	if include {
+		count = len(indexMap)
+	}
 	for _, post := range posts {
 		if createdCount == guild.Limit {
 			break
@@ -190,18 +223,10 @@ func createPixivEmbeds(a *ArtPost, posts []*ugoira.PixivPost, indexMap map[int]b
 			}
 			createdCount++
 
-			var ms *discordgo.MessageSend
+			ms := createPixivEmbed(post, ind, easterEgg, template)
 			if post.Type == "ugoira" && !skipUgoira {
-				err := post.DownloadUgoira()
-				if err != nil {
-					logrus.Warnln(err)
-					ms = createPixivEmbed(post, ind, easterEgg)
-				} else {
-					a.HasUgoira = true
-					ms = createUgoiraEmbed(post, easterEgg)
-				}
-			} else {
-				ms = createPixivEmbed(post, ind, easterEgg)
+				a.HasUgoira = true
+				a.pending = append(a.pending, &pendingUgoira{post, format, ms, createUgoiraEmbed(post, easterEgg, template)})
 			}
 			messages = append(messages, ms)
 
@@ -212,22 +237,28 @@ func createPixivEmbeds(a *ArtPost, posts []*ugoira.PixivPost, indexMap map[int]b
 	}
 
 	if count > guild.Limit {
-		messages[0].Content = fmt.Sprintf("```Album size (%v) is larger than limit set on this server (%v), only first image of every post is reposted.```", count, guild.Limit)
+		messages[0].Content = a.t("repost.limit", count, guild.Limit)
 	}
 
 	if a.IsCrosspost {
-		for _, m := range messages {
+		decorated := make([]*discordgo.MessageSend, 0, len(messages)+len(a.pending))
+		decorated = append(decorated, messages...)
+		for _, p := range a.pending {
+			decorated = append(decorated, p.final)
+		}
+
+		for _, m := range decorated {
 			if strings.Contains(m.Embed.Title, "Page 1") || !strings.Contains(m.Embed.Title, "Page") {
 				m.Content = fmt.Sprintf("<%v>", m.Embed.URL)
 			}
-			m.Embed.Author = &discordgo.MessageEmbedAuthor{Name: fmt.Sprintf("Crosspost requested by %v", a.event.Author.String()), IconURL: a.event.Author.AvatarURL("")}
+			m.Embed.Author = &discordgo.MessageEmbedAuthor{Name: a.t("crosspost.requested_by", a.event.Author.String()), IconURL: a.event.Author.AvatarURL("")}
 		}
 	}
 
 	return messages
 }
 
-func createPixivEmbed(post *ugoira.PixivPost, ind int, easter *embedMessage) *discordgo.MessageSend {
+func createPixivEmbed(post *ugoira.PixivPost, ind int, easter *embedMessage, template *database.EmbedTemplate) *discordgo.MessageSend {
 	title := ""
 
 	if post.Len() == 1 {
@@ -247,39 +278,39 @@ func createPixivEmbed(post *ugoira.PixivPost, ind int, easter *embedMessage) *di
 			URL:       fmt.Sprintf("https://www.pixiv.net/en/artworks/%v", post.ID),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
-			Fields: []*discordgo.MessageEmbedField{
-				{
-					Name:   "Likes",
-					Value:  strconv.Itoa(post.Likes),
-					Inline: true,
-				},
-				{
-					Name:   "Original quality",
-					Value:  fmt.Sprintf("[Click here desu~](%v)", original),
-					Inline: true,
-				},
-			},
+			Fields:    make([]*discordgo.MessageEmbedField, 0, 2),
 			Image: &discordgo.MessageEmbedImage{
 				URL: preview,
 			},
-			Footer: &discordgo.MessageEmbedFooter{
-				Text: easter.Content,
-			},
 		},
 	}
 
-	if ind == 0 {
-		send.Embed.Description = fmt.Sprintf("**Tags**\n%v", joinTags(post.Tags, " • "))
+	if template.Shows("likes") {
+		send.Embed.Fields = append(send.Embed.Fields, &discordgo.MessageEmbedField{Name: "Likes", Value: strconv.Itoa(post.Likes), Inline: true})
+	}
+
+	if template.Shows("original") {
+		send.Embed.Fields = append(send.Embed.Fields, &discordgo.MessageEmbedField{Name: "Original quality", Value: fmt.Sprintf("[Click here desu~](%v)", original), Inline: true})
+	}
+
+	if easter != nil {
+		send.Embed.Footer = &discordgo.MessageEmbedFooter{Text: easter.Content}
+	}
+
+	if ind == 0 && template.Shows("tags") {
+		send.Embed.Description = fmt.Sprintf("**Tags**\n%v", joinTags(template.Tags(post.Tags), " • "))
 	}
 
-	if post.GoodWaifu && strings.Contains(send.Embed.Footer.Text, "Shit waifu") {
+	if post.GoodWaifu && send.Embed.Footer != nil && strings.Contains(send.Embed.Footer.Text, "Shit waifu") {
 		send.Embed.Footer.Text = "Good taste, mate."
 	}
 
+	template.Apply(send.Embed)
 	return send
 }
 
-func createUgoiraEmbed(post *ugoira.PixivPost, easter *embedMessage) *discordgo.MessageSend {
+//createUgoiraEmbed creates an embed for a rendered Ugoira. File is attached when rendering is done.
+func createUgoiraEmbed(post *ugoira.PixivPost, easter *embedMessage, template *database.EmbedTemplate) *discordgo.MessageSend {
 	title := fmt.Sprintf("%v by %v", post.Title, post.Author)
 	send := &discordgo.MessageSend{
 		Embed: &discordgo.MessageEmbed{
@@ -287,27 +318,22 @@ func createUgoiraEmbed(post *ugoira.PixivPost, easter *embedMessage) *discordgo.
 			URL:       fmt.Sprintf("https://www.pixiv.net/en/artworks/%v", post.ID),
 			Color:     utils.EmbedColor,
 			Timestamp: utils.EmbedTimestamp(),
-			Fields: []*discordgo.MessageEmbedField{
-				{
-					Name:   "Likes",
-					Value:  strconv.Itoa(post.Likes),
-					Inline: true,
-				},
-				{
-					Name:   "Tags",
-					Value:  joinTags(post.Tags, " • "),
-					Inline: true,
-				},
-			},
-			Footer: &discordgo.MessageEmbedFooter{
-				Text: easter.Content,
-			},
+			Fields:    make([]*discordgo.MessageEmbedField, 0, 2),
 		},
 	}
 
-	send.Files = append(send.Files, &discordgo.File{
-		Name:   fmt.Sprintf("%v.mp4", post.ID),
-		Reader: post.Ugoira.File,
-	})
+	if template.Shows("likes") {
+		send.Embed.Fields = append(send.Embed.Fields, &discordgo.MessageEmbedField{Name: "Likes", Value: strconv.Itoa(post.Likes), Inline: true})
+	}
+
+	if template.Shows("tags") {
+		send.Embed.Fields = append(send.Embed.Fields, &discordgo.MessageEmbedField{Name: "Tags", Value: joinTags(template.Tags(post.Tags), " • "), Inline: true})
+	}
+
+	if easter != nil {
+		send.Embed.Footer = &discordgo.MessageEmbedFooter{Text: easter.Content}
+	}
+
+	template.Apply(send.Embed)
 	return send
 }
@@ -2,12 +2,15 @@ package repost
 
 import (
 	"fmt"
-	"os"
+	"math/rand"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ReneKroon/ttlcache"
 	"github.com/VTGare/boe-tea-go/internal/database"
+	"github.com/VTGare/boe-tea-go/internal/locale"
+	"github.com/VTGare/boe-tea-go/internal/prompt"
 	"github.com/VTGare/boe-tea-go/internal/ugoira"
 	"github.com/VTGare/boe-tea-go/pkg/tsuita"
 	"github.com/VTGare/boe-tea-go/utils"
@@ -79,15 +82,23 @@ func init() {
 type ArtPost struct {
 	TwitterMatches map[string]bool
 	PixivMatches   map[string]bool
+	Attachments    []*discordgo.MessageAttachment
 	HasUgoira      bool
 	IsCrosspost    bool
 	event          *discordgo.MessageCreate
+	pending        []*pendingUgoira
+	pagesPicked    bool
+	webhook        bool
+	files          []*attachment
+	filesSkipped   []string
+	locales        []string
 }
 
 type SendPixivOptions struct {
-	IndexMap   map[int]bool
-	Include    bool
-	SkipUgoira bool
+	IndexMap     map[int]bool
+	Include      bool
+	SkipUgoira   bool
+	UgoiraFormat ugoira.Format
 }
 
 type embedMessage struct {
@@ -95,6 +106,35 @@ type embedMessage struct {
 	NSFW    bool
 }
 
+//randomFooter picks a footer message according to guild's footer mode. NSFW messages are skipped if a channel prohibits NSFW content.
+//Nil is returned if footers are off or there are no suitable messages.
+func randomFooter(guild *database.GuildSettings) *embedMessage {
+	pool := make([]*embedMessage, 0)
+
+	mode := guild.FooterMode()
+	if mode == database.FootersDefault || mode == database.FootersMixed {
+		if guild.NSFW {
+			pool = append(pool, embedMessages...)
+		} else {
+			pool = append(pool, sfwEmbedMessages...)
+		}
+	}
+
+	if mode == database.FootersCustom || mode == database.FootersMixed {
+		for _, f := range guild.Footers {
+			if guild.NSFW || !f.NSFW {
+				pool = append(pool, &embedMessage{f.Content, f.NSFW})
+			}
+		}
+	}
+
+	if len(pool) == 0 {
+		return nil
+	}
+
+	return pool[rand.Intn(len(pool))]
+}
+
 type CachedMessage struct {
 	session         *discordgo.Session
 	OriginalEmbed   *discordgo.MessageSend
@@ -123,9 +163,10 @@ func (a *ArtPost) PixivArray() []string {
 }
 
 func (a *ArtPost) RepostEmbed(reposts []*database.ImagePost) *discordgo.MessageEmbed {
+	template := database.GuildTemplate(a.event.GuildID, database.RepostEmbed)
 	embed := &discordgo.MessageEmbed{
-		Title:       "General Reposti!",
-		Description: "***Reminder:*** you can look up if things you post have already been posted using Discord's search feature.\nI recommend to check reposts by post's unique identifier.",
+		Title:       a.t("repost.title"),
+		Description: a.t("repost.description"),
 		Thumbnail: &discordgo.MessageEmbedThumbnail{
 			URL: utils.DefaultEmbedImage,
 		},
@@ -133,27 +174,38 @@ func (a *ArtPost) RepostEmbed(reposts []*database.ImagePost) *discordgo.MessageE
 		Color:     utils.EmbedColor,
 	}
 
+	//field names are translated, so template's fields are checked here instead of matching names
 	for _, rep := range reposts {
 		dur := rep.CreatedAt.Add(86400 * time.Second).Sub(time.Now())
-		content := &discordgo.MessageEmbedField{
-			Name:   "Content",
-			Value:  rep.Content,
-			Inline: true,
+		if template.Shows("content") {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   a.t("repost.content"),
+				Value:  rep.Content,
+				Inline: true,
+			})
 		}
-		link := &discordgo.MessageEmbedField{
-			Name:   "Link to post",
-			Value:  fmt.Sprintf("[Press here desu~](https://discord.com/channels/%v/%v/%v)", rep.GuildID, rep.ChannelID, rep.MessageID),
-			Inline: true,
+		if template.Shows("link") {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   a.t("repost.link"),
+				Value:  a.t("repost.link_value", fmt.Sprintf("https://discord.com/channels/%v/%v/%v", rep.GuildID, rep.ChannelID, rep.MessageID)),
+				Inline: true,
+			})
 		}
-		expires := &discordgo.MessageEmbedField{
-			Name:   "Expires",
-			Value:  dur.Round(time.Second).String(),
-			Inline: true,
+		if template.Shows("expires") {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   a.t("repost.expires"),
+				Value:  dur.Round(time.Second).String(),
+				Inline: true,
+			})
 		}
-		embed.Fields = append(embed.Fields, content, link, expires)
 	}
 
-	return embed
+	return template.Apply(embed)
+}
+
+//t translates a message to the language of post's author.
+func (a *ArtPost) t(key string, args ...interface{}) string {
+	return locale.Get(a.locales, key, args...)
 }
 
 func (a *ArtPost) FindReposts(guildID, channelID string) []*database.ImagePost {
@@ -222,27 +274,21 @@ func (a *ArtPost) RemoveReposts(reposts []*database.ImagePost) (pixiv, twitter m
 	return
 }
 
-//Cleanup removes Ugoira files if any
-func (a *ArtPost) Cleanup(posts []*ugoira.PixivPost) {
-	for _, p := range posts {
-		if p.Ugoira != nil && p.Ugoira.File != nil {
-			logrus.Infoln("Removing Ugoira file.")
-			p.Ugoira.File.Close()
-			os.Remove(p.Ugoira.File.Name())
-		}
-	}
-}
-
 func sendMessage(s *discordgo.Session, m *discordgo.MessageCreate, send *discordgo.MessageSend) {
 	msg, err := s.ChannelMessageSendComplex(m.ChannelID, send)
 	if err != nil {
 		logrus.Warnln(err)
 	} else {
-		MsgCache.Set(msg.ChannelID+msg.ID, &CachedMessage{s, send, m, msg})
-		s.MessageReactionAdd(msg.ChannelID, msg.ID, "🔄")
+		cacheMessage(s, m, send, msg)
 	}
 }
 
+func cacheMessage(s *discordgo.Session, m *discordgo.MessageCreate, send *discordgo.MessageSend, msg *discordgo.Message) {
+	MsgCache.Set(msg.ChannelID+msg.ID, &CachedMessage{s, send, m, msg})
+	addChild(m.ID, msg)
+	s.MessageReactionAdd(msg.ChannelID, msg.ID, "🔄")
+}
+
 func (a *ArtPost) Post(s *discordgo.Session, pixivOpts ...SendPixivOptions) error {
 	var (
 		m       = a.event
@@ -250,7 +296,11 @@ func (a *ArtPost) Post(s *discordgo.Session, pixivOpts ...SendPixivOptions) erro
 		twitter = make(map[string]bool)
 	)
 
-	guild := database.GuildCache[m.GuildID]
+	if a.Len() > 0 {
+		trackFamily(m)
+	}
+
+	guild := database.Settings(m.GuildID, m.ChannelID)
 	for k, v := range a.PixivMatches {
 		pixiv[k] = v
 	}
@@ -265,23 +315,23 @@ func (a *ArtPost) Post(s *discordgo.Session, pixivOpts ...SendPixivOptions) erro
 				pixiv, twitter = a.RemoveReposts(reposts)
 
 				s.ChannelMessageSendEmbed(m.ChannelID, a.RepostEmbed(reposts))
-				perm, err := utils.MemberHasPermission(s, m.GuildID, s.State.User.ID, 8|8192)
+				perm, err := utils.MemberHasPermission(s, m.GuildID, m.ChannelID, s.State.User.ID, 8|8192)
 				if err != nil {
 					return err
 				}
 
 				if !perm {
-					s.ChannelMessageSend(m.ChannelID, "Please enable Manage Messages permission to remove reposts with strict mode on, otherwise strict mode is useless.")
+					s.ChannelMessageSend(m.ChannelID, a.t("repost.strict_permission"))
 				} else if len(pixiv)+len(twitter) == 0 {
 					s.ChannelMessageDelete(m.ChannelID, m.ID)
 				}
 			} else if guild.Repost == "enabled" {
 				if a.PixivReposts(reposts) > 0 && guild.Pixiv {
-					prompt := utils.CreatePromptWithMessage(s, m, &discordgo.MessageSend{
-						Content: "Following posts are reposts, react 👌 to post them.",
+					confirmed := prompt.CreateWithMessage(s, m, &discordgo.MessageSend{
+						Content: a.t("repost.prompt"),
 						Embed:   a.RepostEmbed(reposts),
-					})
-					if !prompt {
+					}, guild.ConfirmEmoji())
+					if !confirmed {
 						return nil
 					}
 				} else {
@@ -291,20 +341,28 @@ func (a *ArtPost) Post(s *discordgo.Session, pixivOpts ...SendPixivOptions) erro
 		}
 	}
 
-	var posts []*ugoira.PixivPost
 	if guild.Pixiv && len(pixiv) > 0 {
-		var (
-			messages []*discordgo.MessageSend
-			err      error
-		)
-
-		messages, posts, err = a.SendPixiv(s, pixiv, pixivOpts...)
+		messages, posts, err := a.SendPixiv(s, pixiv, pixivOpts...)
 		if err != nil {
 			return err
 		}
 
-		for _, message := range messages {
-			sendMessage(s, m, message)
+		//messages are capped by the limit, a prompt is about the size of the whole album
+		post := true
+		if count := countPages(posts); guild.LargeSet > 0 && count >= guild.LargeSet && !a.pagesPicked {
+			post = prompt.Create(s, m, &prompt.Options{
+				Actions: map[string]bool{
+					guild.ConfirmEmoji(): true,
+				},
+				Message: a.t("repost.large_set", count),
+				Timeout: 15 * time.Second,
+			})
+		}
+
+		if post {
+			a.sendPixivMessages(s, m, messages)
+		} else {
+			a.pending = nil
 		}
 	}
 
@@ -317,24 +375,24 @@ func (a *ArtPost) Post(s *discordgo.Session, pixivOpts ...SendPixivOptions) erro
 		if len(tweets) > 0 {
 			msg := ""
 
-			prompt := true
+			confirmed := true
 			if guild.TwitterPrompt {
 				if len(tweets) == 1 {
-					msg = "Detected a tweet with more than one image, would you like to send embeds of other images for mobile users?"
+					msg = a.t("repost.tweet_prompt")
 				} else {
-					msg = "Detected tweets with more than one image, would you like to send embeds of other images for mobile users?"
+					msg = a.t("repost.tweets_prompt")
 				}
 
-				prompt = utils.CreatePrompt(s, m, &utils.PromptOptions{
+				confirmed = prompt.Create(s, m, &prompt.Options{
 					Actions: map[string]bool{
-						"👌": true,
+						guild.ConfirmEmoji(): true,
 					},
 					Message: msg,
 					Timeout: 10 * time.Second,
 				})
 			}
 
-			if prompt {
+			if confirmed {
 				for _, t := range tweets {
 					for _, send := range t {
 						sendMessage(s, m, send)
@@ -344,30 +402,151 @@ func (a *ArtPost) Post(s *discordgo.Session, pixivOpts ...SendPixivOptions) erro
 		}
 	}
 
-	if a.HasUgoira {
-		a.Cleanup(posts)
+	return nil
+}
+
+//filter removes posts a cross-post target doesn't allow from Pixiv and Twitter matches.
+func (a *ArtPost) filter(target *database.Target, pixiv, twitter map[string]bool) {
+	if !target.HasFilters() {
+		return
 	}
 
-	return nil
+	for id := range pixiv {
+		post, err := ugoira.GetPixivPost(id)
+		if err != nil {
+			logrus.Warnf("filter(): %v", err)
+			continue
+		}
+
+		if !target.Allows("pixiv", post.Tags, post.NSFW, post.Likes) {
+			delete(pixiv, id)
+		}
+	}
+
+	for id := range twitter {
+		tweet, err := tsuita.GetTweet(fmt.Sprintf("https://twitter.com/i/web/status/%v", id))
+		if err != nil {
+			logrus.Warnf("filter(): %v", err)
+			continue
+		}
+
+		if !target.Allows("twitter", nil, false, tweet.Likes) {
+			delete(twitter, id)
+		}
+	}
 }
 
-func (a *ArtPost) Crosspost(s *discordgo.Session, channels []string, pixivOpts ...SendPixivOptions) error {
+//enforceGuild removes posts a destination channel doesn't accept according to its server settings.
+//It returns reasons posts were removed for.
+func (a *ArtPost) enforceGuild(guild *database.GuildSettings, ch *discordgo.Channel, pixiv, twitter map[string]bool) []string {
 	var (
-		m       = a.event
-		pixiv   = make(map[string]bool)
-		twitter = make(map[string]bool)
+		reasons = make([]string, 0)
+		seen    = make(map[string]bool)
 	)
+
+	skip := func(reason string, posts map[string]bool, ids ...string) {
+		if len(ids) == 0 {
+			for id := range posts {
+				ids = append(ids, id)
+			}
+		}
+
+		for _, id := range ids {
+			delete(posts, id)
+		}
+
+		if !seen[reason] {
+			seen[reason] = true
+			reasons = append(reasons, reason)
+		}
+	}
+
+	if guild == nil || !guild.Crosspost {
+		skip(a.t("crosspost.disabled"), pixiv)
+		skip(a.t("crosspost.disabled"), twitter)
+		return reasons
+	}
+
+	if !guild.Pixiv && len(pixiv) > 0 {
+		skip(a.t("crosspost.no_pixiv"), pixiv)
+	}
+
+	if !guild.Twitter && len(twitter) > 0 {
+		skip(a.t("crosspost.no_tweets"), twitter)
+	}
+
+	for id := range pixiv {
+		post, err := ugoira.GetPixivPost(id)
+		if err != nil || !post.NSFW {
+			continue
+		}
+
+		switch {
+		case !guild.NSFW:
+			skip(a.t("nsfw.server"), pixiv, id)
+		case !ch.NSFW:
+			skip(a.t("nsfw.channel"), pixiv, id)
+		}
+	}
+
+	return reasons
+}
+
+func (a *ArtPost) Crosspost(s *discordgo.Session, targets []*database.Target, pixivOpts ...SendPixivOptions) error {
+	var (
+		m         = a.event
+		pixiv     = make(map[string]bool)
+		twitter   = make(map[string]bool)
+		origin    = m.ChannelID
+		originGID = m.GuildID
+		skipped   = make([]string, 0)
+
+		attachmentsReported bool
+	)
+	if a.Len() == 0 && len(a.Attachments) == 0 {
+		return nil
+	}
+
+	var originNSFW bool
+	if ch, err := s.State.Channel(origin); err == nil {
+		originNSFW = ch.NSFW
+	}
+
 	a.IsCrosspost = true
+	trackFamily(m)
+	markDelivered(origin, a.PixivMatches, a.TwitterMatches)
+
+	defer func() {
+		m.ChannelID = origin
+		m.GuildID = originGID
+		a.webhook = false
+
+		if len(skipped) > 0 {
+			s.ChannelMessageSendEmbed(origin, &discordgo.MessageEmbed{
+				Title:       a.t("crosspost.skipped"),
+				Description: strings.Join(skipped, "\n"),
+				Color:       utils.EmbedColor,
+				Thumbnail:   &discordgo.MessageEmbedThumbnail{URL: utils.DefaultEmbedImage},
+				Timestamp:   utils.EmbedTimestamp(),
+			})
+		}
+	}()
 
-	for _, id := range channels {
-		ch, err := s.State.Channel(id)
+	for _, target := range targets {
+		ch, err := s.State.Channel(target.ChannelID)
 		if err != nil {
 			logrus.Warnf("prefixless(): %v", err)
 			continue
 		}
 
-		m.ChannelID = id
+		if err := utils.CanCrosspost(s, a.event.Author.ID, target.ChannelID); err != nil {
+			skipped = append(skipped, fmt.Sprintf("<#%v>: %v", target.ChannelID, err))
+			continue
+		}
+
+		m.ChannelID = target.ChannelID
 		m.GuildID = ch.GuildID
+		a.webhook = target.Webhook
 		for k, v := range a.PixivMatches {
 			pixiv[k] = v
 		}
@@ -375,11 +554,26 @@ func (a *ArtPost) Crosspost(s *discordgo.Session, channels []string, pixivOpts .
 			twitter[k] = v
 		}
 
-		guild := database.GuildCache[m.GuildID]
-		if guild.Repost != "disabled" {
+		guild := database.Settings(m.GuildID, m.ChannelID)
+		reasons := a.enforceGuild(guild, ch, pixiv, twitter)
+		attachments, reason := a.allowsAttachments(guild, ch, target, originNSFW)
+		if reason != "" && !utils.Contains(reasons, reason) {
+			reasons = append(reasons, reason)
+		}
+		if len(reasons) > 0 {
+			skipped = append(skipped, fmt.Sprintf("<#%v>: %v", ch.ID, strings.Join(reasons, " ")))
+		}
+
+		if guild != nil && guild.Repost != "disabled" {
 			reposts := a.FindReposts(m.GuildID, m.ChannelID)
-			pixiv, twitter = a.RemoveReposts(reposts)
+			for _, r := range reposts {
+				delete(pixiv, r.Content)
+				delete(twitter, r.Content)
+			}
 		}
+		a.filter(target, pixiv, twitter)
+		dropDelivered(target.ChannelID, pixiv, twitter)
+		markDelivered(target.ChannelID, pixiv, twitter)
 
 		if len(pixiv) > 0 {
 			var (
@@ -387,22 +581,12 @@ func (a *ArtPost) Crosspost(s *discordgo.Session, channels []string, pixivOpts .
 				err      error
 			)
 
-			if len(pixivOpts) > 0 {
-				pixivOpts[0].SkipUgoira = true
-				messages, _, err = a.SendPixiv(s, pixiv, pixivOpts[0])
-			} else {
-				messages, _, err = a.SendPixiv(s, pixiv, SendPixivOptions{
-					SkipUgoira: true,
-				})
-			}
-
+			messages, _, err = a.SendPixiv(s, pixiv, pixivOpts...)
 			if err != nil {
 				return err
 			}
 
-			for _, message := range messages {
-				sendMessage(s, m, message)
-			}
+			a.sendPixivMessages(s, m, messages)
 		}
 
 		if len(twitter) > 0 {
@@ -414,11 +598,19 @@ func (a *ArtPost) Crosspost(s *discordgo.Session, channels []string, pixivOpts .
 			if len(tweets) > 0 {
 				for _, t := range tweets {
 					for _, send := range t {
-						sendMessage(s, m, send)
+						a.send(s, m, send)
 					}
 				}
 			}
 		}
+
+		if attachments {
+			a.sendAttachments(s, m)
+			if !attachmentsReported && len(a.filesSkipped) > 0 {
+				skipped = append(skipped, a.filesSkipped...)
+				attachmentsReported = true
+			}
+		}
 	}
 
 	return nil
@@ -450,5 +642,7 @@ func NewPost(m *discordgo.MessageCreate, content ...string) *ArtPost {
 		event:          m,
 		TwitterMatches: twitter,
 		PixivMatches:   IDs,
+		Attachments:    imageAttachments(m.Message),
+		locales:        database.Locales(m.GuildID, m.Author.ID),
 	}
 }
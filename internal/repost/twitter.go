@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/VTGare/boe-tea-go/internal/database"
 	"github.com/VTGare/boe-tea-go/pkg/tsuita"
 	"github.com/VTGare/boe-tea-go/utils"
 	"github.com/bwmarrin/discordgo"
@@ -97,6 +98,7 @@ func (a *ArtPost) tweetToEmbeds(tweet *tsuita.Tweet, skipFirst bool) ([]*discord
 	var (
 		messages = make([]*discordgo.MessageSend, 0)
 		ind      = 0
+		template = database.GuildTemplate(a.event.GuildID, database.TwitterEmbed)
 	)
 
 	if skipFirst {
@@ -127,26 +129,23 @@ func (a *ArtPost) tweetToEmbeds(tweet *tsuita.Tweet, skipFirst bool) ([]*discord
 			URL:       tweet.URL,
 			Timestamp: tweet.Timestamp,
 			Color:     utils.EmbedColor,
-			Fields: []*discordgo.MessageEmbedField{
-				{
-					Name:   "Retweets",
-					Value:  strconv.Itoa(tweet.Retweets),
-					Inline: true,
-				},
-				{
-					Name:   "Likes",
-					Value:  strconv.Itoa(tweet.Likes),
-					Inline: true,
-				},
-			},
+			Fields:    make([]*discordgo.MessageEmbedField, 0, 2),
 			Footer: &discordgo.MessageEmbedFooter{
 				IconURL: twitterLogo,
 				Text:    "Twitter",
 			},
 		}
 
+		if template.Shows("retweets") {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Retweets", Value: strconv.Itoa(tweet.Retweets), Inline: true})
+		}
+
+		if template.Shows("likes") {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Likes", Value: strconv.Itoa(tweet.Likes), Inline: true})
+		}
+
 		msg := &discordgo.MessageSend{}
-		if ind == 0 {
+		if ind == 0 && template.Shows("text") {
 			embed.Description = tweet.Content
 		}
 
@@ -167,10 +166,10 @@ func (a *ArtPost) tweetToEmbeds(tweet *tsuita.Tweet, skipFirst bool) ([]*discord
 				URL: media.URL,
 			}
 		}
-		msg.Embed = &embed
+		msg.Embed = template.Apply(&embed)
 
 		if a.IsCrosspost {
-			msg.Embed.Author = &discordgo.MessageEmbedAuthor{Name: fmt.Sprintf("Crosspost requested by %v", a.event.Author.String()), IconURL: a.event.Author.AvatarURL("")}
+			msg.Embed.Author = &discordgo.MessageEmbedAuthor{Name: a.t("crosspost.requested_by", a.event.Author.String()), IconURL: a.event.Author.AvatarURL("")}
 		}
 		messages = append(messages, msg)
 	}
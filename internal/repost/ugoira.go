@@ -0,0 +1,141 @@
+package repost
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VTGare/boe-tea-go/internal/locale"
+	"github.com/VTGare/boe-tea-go/internal/ugoira"
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	renderTimeout = 5 * time.Minute
+	renders       = make(map[string]map[*pendingUgoira]context.CancelFunc)
+	rendersMu     sync.Mutex
+)
+
+//pendingUgoira is an Ugoira waiting to be rendered. Placeholder is a static embed sent while it renders.
+type pendingUgoira struct {
+	post        *ugoira.PixivPost
+	format      ugoira.Format
+	placeholder *discordgo.MessageSend
+	final       *discordgo.MessageSend
+}
+
+//CancelRenders cancels all Ugoira renders requested by a message. Used when the message is deleted.
+func CancelRenders(messageID string) {
+	rendersMu.Lock()
+	defer rendersMu.Unlock()
+
+	for _, cancel := range renders[messageID] {
+		cancel()
+	}
+	delete(renders, messageID)
+}
+
+func addRender(messageID string, p *pendingUgoira, cancel context.CancelFunc) {
+	rendersMu.Lock()
+	defer rendersMu.Unlock()
+
+	if _, ok := renders[messageID]; !ok {
+		renders[messageID] = make(map[*pendingUgoira]context.CancelFunc)
+	}
+	renders[messageID][p] = cancel
+}
+
+func removeRender(messageID string, p *pendingUgoira) {
+	rendersMu.Lock()
+	defer rendersMu.Unlock()
+
+	delete(renders[messageID], p)
+	if len(renders[messageID]) == 0 {
+		delete(renders, messageID)
+	}
+}
+
+func (a *ArtPost) pendingFor(send *discordgo.MessageSend) *pendingUgoira {
+	for _, p := range a.pending {
+		if p.placeholder == send {
+			return p
+		}
+	}
+	return nil
+}
+
+//sendPixivMessages sends Pixiv embeds, Ugoira are sent as placeholders and rendered in the background.
+func (a *ArtPost) sendPixivMessages(s *discordgo.Session, m *discordgo.MessageCreate, messages []*discordgo.MessageSend) {
+	for _, message := range messages {
+		if p := a.pendingFor(message); p != nil {
+			a.sendUgoira(s, m, p)
+		} else {
+			a.send(s, m, message)
+		}
+	}
+
+	a.pending = nil
+}
+
+//sendUgoira sends a placeholder and queues a render. Webhook mode and locales of a cross-post target are captured before the render,
+//both the placeholder and the animation are sent the same way.
+func (a *ArtPost) sendUgoira(s *discordgo.Session, m *discordgo.MessageCreate, p *pendingUgoira) {
+	var (
+		webhook = a.webhook
+		locales = a.locales
+	)
+
+	placeholder, err := postMessage(s, m, &discordgo.MessageSend{
+		Content: strings.TrimSpace(p.placeholder.Content + "\n" + locale.Get(locales, "ugoira.rendering")),
+		Embed:   p.placeholder.Embed,
+	}, webhook)
+	if err != nil {
+		logrus.Warnln(err)
+		return
+	}
+
+	//event is copied because crossposting reuses and modifies the original one
+	var (
+		msg   = *m.Message
+		event = &discordgo.MessageCreate{Message: &msg}
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), renderTimeout)
+	addRender(m.ID, p, cancel)
+
+	go func() {
+		defer cancel()
+		defer removeRender(event.ID, p)
+
+		u, err := ugoira.RenderQueue.Render(ctx, p.post, p.format, func(position int) {
+			status := locale.Get(locales, "ugoira.rendering")
+			if position > 0 {
+				status = locale.Get(locales, "ugoira.queued", position)
+			}
+
+			editContent(s, placeholder, strings.TrimSpace(p.placeholder.Content+"\n"+status))
+		})
+
+		switch {
+		case ctx.Err() == context.Canceled:
+			logrus.Infof("Ugoira render cancelled. ID: %v", p.post.ID)
+			deleteMessage(s, placeholder)
+		case err != nil:
+			logrus.Warnf("RenderQueue.Render(): %v", err)
+			editContent(s, placeholder, p.placeholder.Content)
+		default:
+			defer u.File.Close()
+
+			p.final.Files = []*discordgo.File{{
+				Name:   fmt.Sprintf("%v.%v", p.post.ID, u.Format.Extension()),
+				Reader: u.File,
+			}}
+
+			deliver(s, event, p.final, webhook)
+			deleteMessage(s, placeholder)
+		}
+	}()
+}
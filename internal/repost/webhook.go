@@ -0,0 +1,209 @@
+package repost
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	webhooks   = make(map[string]*discordgo.Webhook)
+	webhooksMu sync.Mutex
+)
+
+const webhookName = "Boe Tea Crossposts"
+
+//channelWebhook returns a cached webhook of a channel. Webhook is looked up or created lazily.
+func channelWebhook(s *discordgo.Session, channelID string) (*discordgo.Webhook, error) {
+	webhooksMu.Lock()
+	defer webhooksMu.Unlock()
+
+	if wh, ok := webhooks[channelID]; ok {
+		return wh, nil
+	}
+
+	hooks, err := s.ChannelWebhooks(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, wh := range hooks {
+		if wh.User != nil && wh.User.ID == s.State.User.ID && wh.Token != "" {
+			webhooks[channelID] = wh
+			return wh, nil
+		}
+	}
+
+	wh, err := s.WebhookCreate(channelID, webhookName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks[channelID] = wh
+	return wh, nil
+}
+
+func forgetWebhook(channelID string) {
+	webhooksMu.Lock()
+	delete(webhooks, channelID)
+	webhooksMu.Unlock()
+}
+
+func isUnknownWebhook(err error) bool {
+	restErr, ok := err.(*discordgo.RESTError)
+	if !ok {
+		return false
+	}
+
+	if restErr.Message != nil && restErr.Message.Code == discordgo.ErrCodeUnknownWebhook {
+		return true
+	}
+
+	return restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound
+}
+
+//executeWebhook sends a message through channel's webhook under the name and avatar of a message author.
+//Deleted webhooks are recreated once.
+func executeWebhook(s *discordgo.Session, m *discordgo.MessageCreate, send *discordgo.MessageSend) (*discordgo.Message, error) {
+	name := m.Author.Username
+	if m.Member != nil && m.Member.Nick != "" {
+		name = m.Member.Nick
+	}
+
+	params := &discordgo.WebhookParams{
+		Content:         send.Content,
+		Username:        name,
+		AvatarURL:       m.Author.AvatarURL(""),
+		AllowedMentions: send.AllowedMentions,
+	}
+
+	//author line is redundant, webhook already shows who requested the crosspost
+	if send.Embed != nil {
+		embed := *send.Embed
+		embed.Author = nil
+		params.Embeds = []*discordgo.MessageEmbed{&embed}
+	}
+
+	var (
+		msg *discordgo.Message
+		err error
+	)
+
+	for attempt := 0; attempt < 2; attempt++ {
+		var wh *discordgo.Webhook
+		wh, err = channelWebhook(s, m.ChannelID)
+		if err != nil {
+			return nil, err
+		}
+
+		params.Files = rewindFiles(send.Files)
+		msg, err = s.WebhookExecute(wh.ID, wh.Token, true, params)
+		if err == nil || !isUnknownWebhook(err) {
+			break
+		}
+
+		logrus.Infof("Webhook of channel %v has been deleted, recreating.", m.ChannelID)
+		forgetWebhook(m.ChannelID)
+	}
+
+	return msg, err
+}
+
+//send sends a message through a webhook if it's enabled for a current cross-post target.
+func (a *ArtPost) send(s *discordgo.Session, m *discordgo.MessageCreate, send *discordgo.MessageSend) {
+	deliver(s, m, send, a.webhook)
+}
+
+//deliver sends and caches a message, through channel's webhook if webhook is true.
+func deliver(s *discordgo.Session, m *discordgo.MessageCreate, send *discordgo.MessageSend, webhook bool) {
+	msg, err := postMessage(s, m, send, webhook)
+	if err != nil {
+		logrus.Warnln(err)
+		return
+	}
+
+	cacheMessage(s, m, send, msg)
+}
+
+//postMessage sends a message through channel's webhook if webhook is true, the message isn't cached.
+//Messages with files that can't be re-read and failed webhook calls fall back to regular messages.
+func postMessage(s *discordgo.Session, m *discordgo.MessageCreate, send *discordgo.MessageSend, webhook bool) (*discordgo.Message, error) {
+	if webhook && send.File == nil && seekable(send.Files) {
+		msg, err := executeWebhook(s, m, send)
+		if err == nil {
+			return msg, nil
+		}
+
+		logrus.Warnf("executeWebhook(): %v", err)
+		rewindFiles(send.Files)
+	}
+
+	return s.ChannelMessageSendComplex(m.ChannelID, send)
+}
+
+//messageWebhook returns a webhook that sent a message, nil if it wasn't sent by Boe Tea's webhook.
+func messageWebhook(s *discordgo.Session, msg *discordgo.Message) *discordgo.Webhook {
+	if msg.WebhookID == "" {
+		return nil
+	}
+
+	wh, err := channelWebhook(s, msg.ChannelID)
+	if err != nil || wh.ID != msg.WebhookID {
+		return nil
+	}
+
+	return wh
+}
+
+//editContent edits content of a message. Webhook messages can only be edited through their webhook.
+func editContent(s *discordgo.Session, msg *discordgo.Message, content string) {
+	var err error
+	if wh := messageWebhook(s, msg); wh != nil {
+		_, err = s.WebhookMessageEdit(wh.ID, wh.Token, msg.ID, &discordgo.WebhookEdit{Content: &content})
+	} else {
+		_, err = s.ChannelMessageEdit(msg.ChannelID, msg.ID, content)
+	}
+
+	if err != nil {
+		logrus.Warnf("editContent(): %v", err)
+	}
+}
+
+//deleteMessage deletes a message, webhook messages are deleted through their webhook.
+func deleteMessage(s *discordgo.Session, msg *discordgo.Message) {
+	var err error
+	if wh := messageWebhook(s, msg); wh != nil {
+		err = s.WebhookMessageDelete(wh.ID, wh.Token, msg.ID)
+	} else {
+		err = s.ChannelMessageDelete(msg.ChannelID, msg.ID)
+	}
+
+	if err != nil {
+		logrus.Warnf("deleteMessage(): %v", err)
+	}
+}
+
+//seekable reports whether every file can be read again after a failed attempt.
+func seekable(files []*discordgo.File) bool {
+	for _, f := range files {
+		if _, ok := f.Reader.(io.Seeker); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+//rewindFiles seeks files back to the start so they can be uploaded again.
+func rewindFiles(files []*discordgo.File) []*discordgo.File {
+	for _, f := range files {
+		if seeker, ok := f.Reader.(io.Seeker); ok {
+			seeker.Seek(0, io.SeekStart)
+		}
+	}
+
+	return files
+}
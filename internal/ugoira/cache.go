@@ -0,0 +1,192 @@
+package ugoira
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+//Cache is a size-bounded on-disk LRU cache of rendered Ugoira files. Files are keyed by Pixiv ID and requested format.
+type Cache struct {
+	dir     string
+	maxSize int64
+	size    int64
+	mu      sync.Mutex
+	lru     *list.List
+	items   map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key    string
+	path   string
+	format Format
+	size   int64
+}
+
+//NewCache creates a cache in a given directory and loads files left from previous runs.
+func NewCache(dir string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	c := &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		lru:     list.New(),
+		items:   make(map[string]*list.Element),
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	//oldest files go to the back of the list
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().After(files[j].ModTime())
+	})
+
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		//file names look like <id>.<requested format>.<extension>
+		parts := strings.Split(f.Name(), ".")
+		if len(parts) != 3 {
+			continue
+		}
+
+		format := Format(parts[2])
+		if format == "png" {
+			format = FormatAPNG
+		}
+
+		key := cacheKey(parts[0], Format(parts[1]))
+		c.items[key] = c.lru.PushBack(&cacheEntry{key, filepath.Join(dir, f.Name()), format, f.Size()})
+		c.size += f.Size()
+	}
+
+	c.mu.Lock()
+	c.evict()
+	c.mu.Unlock()
+
+	log.Infof("Loaded ugoira cache. Files: %v, size: %v MB", c.lru.Len(), c.size/1024/1024)
+	return c, nil
+}
+
+func cacheKey(id string, format Format) string {
+	return id + "." + string(format)
+}
+
+//Get returns a path to a cached file and its actual format, which may differ from requested one if rendering fell back.
+func (c *Cache) Get(id string, format Format) (string, Format, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[cacheKey(id, format)]
+	if !ok {
+		return "", "", false
+	}
+
+	entry := el.Value.(*cacheEntry)
+	if _, err := os.Stat(entry.path); err != nil {
+		c.remove(el)
+		return "", "", false
+	}
+
+	c.lru.MoveToFront(el)
+	now := time.Now()
+	os.Chtimes(entry.path, now, now)
+
+	return entry.path, entry.format, true
+}
+
+//Put moves a rendered file into the cache and returns its new path.
+func (c *Cache) Put(id string, requested, actual Format, src string) (string, error) {
+	var (
+		key  = cacheKey(id, requested)
+		dest = filepath.Join(c.dir, fmt.Sprintf("%v.%v", key, actual.Extension()))
+	)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+
+	if err := move(src, dest); err != nil {
+		return "", err
+	}
+
+	stat, err := os.Stat(dest)
+	if err != nil {
+		return "", err
+	}
+
+	c.items[key] = c.lru.PushFront(&cacheEntry{key, dest, actual, stat.Size()})
+	c.size += stat.Size()
+	c.evict()
+
+	return dest, nil
+}
+
+//evict removes least recently used files until cache fits into its size. Most recent file is always kept.
+func (c *Cache) evict() {
+	for c.size > c.maxSize && c.lru.Len() > 1 {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *Cache) remove(el *list.Element) {
+	entry := el.Value.(*cacheEntry)
+
+	c.lru.Remove(el)
+	delete(c.items, entry.key)
+	c.size -= entry.size
+
+	if err := os.Remove(entry.path); err != nil && !os.IsNotExist(err) {
+		log.Warnf("Cache.remove(): %v", err)
+	}
+}
+
+//move renames a file, falls back to copying if the rename fails (e.g. across file systems).
+func move(src, dest string) error {
+	if err := os.Rename(src, dest); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dest)
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		os.Remove(dest)
+		return err
+	}
+
+	return os.Remove(src)
+}
@@ -0,0 +1,127 @@
+package ugoira
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func unzip(src, dest string) ([]string, error) {
+	filenames := make([]string, 0)
+
+	r, err := zip.OpenReader(src)
+	if err != nil {
+		return filenames, err
+	}
+
+	defer r.Close()
+	for _, file := range r.File {
+		fpath := filepath.Join(dest, file.Name)
+
+		if !strings.HasPrefix(fpath, filepath.Clean(dest)+string(os.PathSeparator)) {
+			return filenames, fmt.Errorf("%s: illegal file path", fpath)
+		}
+
+		filenames = append(filenames, fpath)
+
+		if file.FileInfo().IsDir() {
+			os.MkdirAll(fpath, os.ModePerm)
+			continue
+		}
+
+		if err = os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
+			return filenames, err
+		}
+
+		outFile, err := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
+		if err != nil {
+			return filenames, err
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return filenames, err
+		}
+
+		_, err = io.Copy(outFile, rc)
+
+		outFile.Close()
+		rc.Close()
+
+		if err != nil {
+			return filenames, err
+		}
+	}
+
+	return filenames, nil
+}
+
+//encode renders unzipped frames into a file of a given format. Scale is applied to both dimensions.
+func encode(ctx context.Context, folder string, u *Ugoira, format Format, scale float64) (string, error) {
+	var (
+		out  = fmt.Sprintf("%v_%v.%v", folder, strconv.Itoa(int(scale*100)), format.Extension())
+		args = make([]string, 0)
+	)
+
+	//MP4 doesn't loop inline, so short animations are looped up to 10 seconds.
+	if format == FormatMP4 && u.Duration() < 10.0 {
+		args = append(args, "-loop", "1")
+	}
+	args = append(args, "-y", "-framerate", strconv.Itoa(u.FPS()), "-i", folder+"/%06d.jpg")
+
+	//libx264 and libvpx require even dimensions
+	filter := fmt.Sprintf("scale=trunc(iw*%v/2)*2:trunc(ih*%v/2)*2", scale, scale)
+	switch format {
+	case FormatGIF:
+		filter += ",split[s0][s1];[s0]palettegen=stats_mode=diff[p];[s1][p]paletteuse=dither=bayer"
+		args = append(args, "-vf", filter, "-loop", "0")
+	case FormatWebM:
+		args = append(args, "-vf", filter, "-c:v", "libvpx-vp9", "-b:v", "0", "-crf", "32", "-pix_fmt", "yuv420p")
+	case FormatAPNG:
+		args = append(args, "-vf", filter, "-plays", "0", "-f", "apng")
+	default:
+		args = append(args, "-vf", filter, "-c:v", "libx264", "-pix_fmt", "yuv420p")
+		if u.Duration() < 10.0 {
+			args = append(args, "-t", "10")
+		}
+	}
+	args = append(args, out)
+
+	if err := runCmd(ctx, "ffmpeg", args...); err != nil {
+		return "", err
+	}
+
+	return out, nil
+}
+
+func readAndPrint(r io.Reader) {
+	io.Copy(os.Stdout, r)
+}
+
+func runCmd(ctx context.Context, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	go readAndPrint(stdout)
+	go readAndPrint(stderr)
+
+	err = cmd.Run()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
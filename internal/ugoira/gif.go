@@ -0,0 +1,60 @@
+package ugoira
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/VTGare/boe-tea-go/pkg/ugoku"
+	log "github.com/sirupsen/logrus"
+)
+
+//hasFFmpeg reports whether ffmpeg binary is available on PATH.
+func hasFFmpeg() bool {
+	_, err := exec.LookPath("ffmpeg")
+	return err == nil
+}
+
+//renderGIF renders Ugoira to GIF without ffmpeg next to the archive and returns a path to the file. Used as a fallback, ignores requested format.
+func (u *Ugoira) renderGIF(ctx context.Context, archive string) (string, error) {
+	frames := make([]ugoku.Frame, 0, len(u.Metadata.Frames))
+	for _, f := range u.Metadata.Frames {
+		frames = append(frames, ugoku.Frame{File: f.File, Delay: f.Delay})
+	}
+
+	base := strings.TrimSuffix(archive, ".zip")
+	for _, scale := range scales {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		out := fmt.Sprintf("%v_%v.gif", base, int(scale*100))
+		file, err := os.Create(out)
+		if err != nil {
+			return "", err
+		}
+
+		err = ugoku.EncodeGIF(file, archive, frames, scale)
+		file.Close()
+		if err != nil {
+			return "", err
+		}
+
+		stat, err := os.Stat(out)
+		if err != nil {
+			return "", err
+		}
+
+		if stat.Size() > UploadLimit {
+			log.Infof("Ugoira %v is too large. Format: gif (pure Go), scale: %v, size: %v", u.ID, scale, stat.Size())
+			continue
+		}
+
+		u.Format = FormatGIF
+		return out, nil
+	}
+
+	return "", fmt.Errorf("ugoira %v exceeds upload limit", u.ID)
+}
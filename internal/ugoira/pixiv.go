@@ -1,8 +1,10 @@
 package ugoira
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -18,6 +20,11 @@ var (
 	app        *pixiv.AppPixivAPI
 	pixivCache *ttlcache.Cache
 	goodWaifus = map[string]bool{"星街すいせい": true, "ヨルハ二号B型": true, "2B": true, "牧瀬紅莉栖": true, "宝鐘マリン": true}
+
+	//Renders is a persistent cache of rendered Ugoira files.
+	Renders *Cache
+	//RenderQueue limits concurrent Ugoira renders.
+	RenderQueue *Queue
 )
 
 type PixivPost struct {
@@ -27,7 +34,6 @@ type PixivPost struct {
 	Title     string
 	Likes     int
 	Pages     int
-	Ugoira    *Ugoira
 	Tags      []string
 	Images    *PixivImages
 	NSFW      bool
@@ -81,20 +87,91 @@ func init() {
 
 	pixivCache = ttlcache.NewCache()
 	pixivCache.SetTTL(60 * time.Minute)
+
+	cacheDir := os.Getenv("UGOIRA_CACHE_DIR")
+	if cacheDir == "" {
+		cacheDir = filepath.Join("cache", "ugoira")
+	}
+
+	cacheSize := int64(512)
+	if env := os.Getenv("UGOIRA_CACHE_SIZE"); env != "" {
+		cacheSize, err = strconv.ParseInt(env, 10, 64)
+		if err != nil {
+			log.Fatalln("UGOIRA_CACHE_SIZE must be an integer amount of megabytes")
+		}
+	}
+
+	TempRoot = os.Getenv("UGOIRA_TEMP_DIR")
+	if TempRoot == "" {
+		TempRoot = filepath.Join(os.TempDir(), "boe-tea-ugoira")
+	}
+
+	if err := os.MkdirAll(TempRoot, os.ModePerm); err != nil {
+		log.Fatalln("os.MkdirAll():", err)
+	}
+	sweepWorkspaces()
+
+	Renders, err = NewCache(cacheDir, cacheSize*1024*1024)
+	if err != nil {
+		log.Fatalln("NewCache():", err)
+	}
+
+	workers, depth := 2, 20
+	if env := os.Getenv("UGOIRA_WORKERS"); env != "" {
+		workers, err = strconv.Atoi(env)
+		if err != nil || workers < 1 {
+			log.Fatalln("UGOIRA_WORKERS must be a positive integer")
+		}
+	}
+
+	if env := os.Getenv("UGOIRA_QUEUE"); env != "" {
+		depth, err = strconv.Atoi(env)
+		if err != nil || depth < 0 {
+			log.Fatalln("UGOIRA_QUEUE must be a non-negative integer")
+		}
+	}
+
+	RenderQueue = NewQueue(workers, depth)
 }
 
-func (p *PixivPost) DownloadUgoira() error {
+//DownloadUgoira returns Ugoira rendered in a given format with an opened file. Rendered files are cached, caller must close the file.
+//Use RenderQueue instead of calling it directly to limit concurrent renders.
+func (p *PixivPost) DownloadUgoira(ctx context.Context, format Format) (*Ugoira, error) {
+	if path, actual, ok := Renders.Get(p.ID, format); ok {
+		file, err := os.Open(path)
+		if err == nil {
+			log.Infof("Found cached ugoira %v. Format: %v", p.ID, actual)
+			return &Ugoira{ID: p.ID, File: file, Format: actual}, nil
+		}
+	}
+
 	u, err := NewUgoira(p.ID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	dir, err := newWorkspace(p.ID)
+	if err != nil {
+		return nil, err
 	}
-	err = u.toWebm()
+	defer removeWorkspace(dir)
+
+	out, err := u.render(ctx, dir, format)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	path, err := Renders.Put(p.ID, format, u.Format, out)
+	if err != nil {
+		return nil, err
+	}
+
+	u.File, err = os.Open(path)
+	if err != nil {
+		return nil, err
 	}
 
-	p.Ugoira = u
-	return nil
+	return u, nil
 }
 
 //GetPixivPost perfoms a Pixiv API call and returns an array of high-resolution image URLs
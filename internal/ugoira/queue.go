@@ -0,0 +1,128 @@
+package ugoira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	//ErrQueueFull is returned when render queue has no free slots.
+	ErrQueueFull = errors.New("ugoira render queue is full, please try again later")
+)
+
+//Queue is a bounded pool of Ugoira render workers. It limits a number of concurrent ffmpeg processes and a number of waiting jobs.
+type Queue struct {
+	jobs    chan *job
+	mu      sync.Mutex
+	waiting int
+}
+
+type job struct {
+	ctx      context.Context
+	post     *PixivPost
+	format   Format
+	progress func(int)
+	result   chan *jobResult
+}
+
+type jobResult struct {
+	ugoira *Ugoira
+	err    error
+}
+
+//NewQueue creates a Queue and starts its workers.
+func NewQueue(workers, depth int) *Queue {
+	q := &Queue{
+		jobs: make(chan *job, depth),
+	}
+
+	for i := 0; i < workers; i++ {
+		go q.worker()
+	}
+
+	return q
+}
+
+func (q *Queue) worker() {
+	for j := range q.jobs {
+		q.mu.Lock()
+		q.waiting--
+		q.mu.Unlock()
+
+		if j.ctx.Err() != nil {
+			log.Infof("Skipping cancelled ugoira render. ID: %v", j.post.ID)
+			j.result <- &jobResult{nil, j.ctx.Err()}
+			continue
+		}
+
+		if j.progress != nil {
+			j.progress(0)
+		}
+
+		j.result <- q.run(j)
+	}
+}
+
+//run renders a job. A panic fails only the job, its workspace is removed by deferred cleanup.
+func (q *Queue) run(j *job) (res *jobResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("Ugoira render panicked. ID: %v. Panic: %v", j.post.ID, r)
+			res = &jobResult{nil, fmt.Errorf("ugoira render has failed: %v", r)}
+		}
+	}()
+
+	u, err := j.post.DownloadUgoira(j.ctx, j.format)
+	return &jobResult{u, err}
+}
+
+//Render renders Ugoira through the queue and blocks until it's done or context is cancelled.
+//Progress is called with a queue position when a job is queued and with 0 when rendering starts.
+//Cached renders skip the queue.
+func (q *Queue) Render(ctx context.Context, post *PixivPost, format Format, progress func(int)) (*Ugoira, error) {
+	if _, _, ok := Renders.Get(post.ID, format); ok {
+		return post.DownloadUgoira(ctx, format)
+	}
+
+	j := &job{ctx, post, format, progress, make(chan *jobResult, 1)}
+
+	q.mu.Lock()
+	select {
+	case q.jobs <- j:
+		q.waiting++
+	default:
+		q.mu.Unlock()
+		return nil, ErrQueueFull
+	}
+	position := q.waiting
+	q.mu.Unlock()
+
+	if progress != nil {
+		progress(position)
+	}
+
+	select {
+	case res := <-j.result:
+		return res.ugoira, res.err
+	case <-ctx.Done():
+		//worker will close the file if the job finishes after cancellation
+		go func() {
+			if res := <-j.result; res.ugoira != nil && res.ugoira.File != nil {
+				res.ugoira.File.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+//String returns a short description of queue's state.
+func (q *Queue) String() string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return fmt.Sprintf("%v/%v waiting", q.waiting, cap(q.jobs))
+}
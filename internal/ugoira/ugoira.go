@@ -2,26 +2,70 @@ package ugoira
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"math"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/VTGare/pixiv"
+	log "github.com/sirupsen/logrus"
 	"github.com/valyala/fasthttp"
 )
 
 var (
 	client = http.DefaultClient
+	//UploadLimit is Discord's file size limit for bot uploads in bytes.
+	UploadLimit int64 = 8 * 1024 * 1024
+
+	//fallbacks is an order of formats tried when rendered file doesn't fit into the upload limit.
+	fallbacks = map[Format][]Format{
+		FormatGIF:  {FormatGIF, FormatWebM, FormatMP4},
+		FormatAPNG: {FormatAPNG, FormatGIF, FormatWebM, FormatMP4},
+		FormatWebM: {FormatWebM, FormatMP4},
+		FormatMP4:  {FormatMP4},
+	}
+	scales = []float64{1, 0.75, 0.5}
+)
+
+//Format is an output format of a rendered Ugoira.
+type Format string
+
+const (
+	FormatMP4  Format = "mp4"
+	FormatGIF  Format = "gif"
+	FormatWebM Format = "webm"
+	FormatAPNG Format = "apng"
 )
 
+//ParseFormat converts a string to an Ugoira Format.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatMP4, FormatGIF, FormatWebM, FormatAPNG:
+		return f, nil
+	case "":
+		return FormatMP4, nil
+	}
+
+	return "", fmt.Errorf("unknown ugoira format %v. Available formats: mp4, gif, webm, apng", s)
+}
+
+//Extension returns a file extension of the format.
+func (f Format) Extension() string {
+	if f == FormatAPNG {
+		return "png"
+	}
+	return string(f)
+}
+
 type Ugoira struct {
 	ID       string
 	File     *os.File
+	Format   Format
 	Error    bool   `json:"error"`
 	Message  string `json:"message"`
 	Metadata *pixiv.UgoiraMetadataClass
@@ -38,36 +82,60 @@ func NewUgoira(id string) (*Ugoira, error) {
 		return nil, err
 	}
 
-	return &Ugoira{id, nil, false, "", &metadata.UgoiraMetadataUgoiraMetadata}, nil
+	return &Ugoira{ID: id, Metadata: &metadata.UgoiraMetadataUgoiraMetadata}, nil
 }
 
-func (u *Ugoira) toWebm() error {
-	zip, err := downloadZIP(u)
+//render renders Ugoira in a given format inside a job directory and returns a path to the file. If the result exceeds UploadLimit, it falls back to smaller formats and lower resolutions.
+func (u *Ugoira) render(ctx context.Context, dir string, format Format) (string, error) {
+	archive, err := downloadZIP(u, dir)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	folder := strings.TrimSuffix(zip.Name(), ".zip")
-	_, err = unzip(zip.Name(), folder)
-	if err != nil {
-		return err
+	if !hasFFmpeg() {
+		log.Infof("ffmpeg is not available, rendering ugoira %v with pure Go encoder", u.ID)
+		return u.renderGIF(ctx, archive)
 	}
 
-	webm, err := makeWebm(folder, u)
+	folder := filepath.Join(dir, "frames")
+	_, err = unzip(archive, folder)
 	if err != nil {
-		return err
+		return "", err
 	}
-	os.RemoveAll(folder)
-	zip.Close()
-	os.Remove(zip.Name())
 
-	file, err := os.Open(webm)
-	if err != nil {
-		return err
+	var lastErr error
+	for _, f := range fallbacks[format] {
+		for _, scale := range scales {
+			out, err := encode(ctx, folder, u, f, scale)
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+
+			if err != nil {
+				log.Warnf("encode(): %v. Format: %v", err, f)
+				lastErr = err
+				break
+			}
+
+			stat, err := os.Stat(out)
+			if err != nil {
+				return "", err
+			}
+
+			if stat.Size() > UploadLimit {
+				log.Infof("Ugoira %v is too large. Format: %v, scale: %v, size: %v", u.ID, f, scale, stat.Size())
+				continue
+			}
+
+			u.Format = f
+			return out, nil
+		}
 	}
 
-	u.File = file
-	return nil
+	if lastErr != nil {
+		return "", lastErr
+	}
+	return "", fmt.Errorf("ugoira %v exceeds upload limit in every format", u.ID)
 }
 
 func (u *Ugoira) Duration() float64 {
@@ -110,21 +178,24 @@ func fasthttpGet(uri, id string) ([]byte, error) {
 	return resp.Body(), nil
 }
 
-func downloadZIP(ugoira *Ugoira) (*os.File, error) {
+//downloadZIP downloads Ugoira frames archive into a job directory and returns its path.
+func downloadZIP(ugoira *Ugoira, dir string) (string, error) {
 	body, err := fasthttpGet(ugoira.Metadata.ZipURLs.Medium, ugoira.ID)
 	if err != nil {
-		return nil, fmt.Errorf("downloadZIP(): %v", err)
+		return "", fmt.Errorf("downloadZIP(): %v", err)
 	}
 
-	file, err := os.Create(fmt.Sprintf("temp_%v_%v.zip", time.Now().Format("15-04-05"), ugoira.ID))
+	path := filepath.Join(dir, "ugoira.zip")
+	file, err := os.Create(path)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	defer file.Close()
 
 	_, err = io.Copy(file, bytes.NewReader(body))
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return file, nil
+	return path, nil
 }
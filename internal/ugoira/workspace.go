@@ -0,0 +1,93 @@
+package ugoira
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	//TempRoot is a directory for Ugoira job directories.
+	TempRoot string
+	//orphanAge is an age after which a job directory is considered abandoned. Directories of running renders are never removed.
+	orphanAge = 10 * time.Minute
+
+	//active is a set of job directories in use by this process.
+	active   = make(map[string]bool)
+	activeMu sync.Mutex
+)
+
+//newWorkspace creates a unique job directory for a single render. It's kept from sweeps until removeWorkspace is called.
+func newWorkspace(id string) (string, error) {
+	dir, err := ioutil.TempDir(TempRoot, id+"-")
+	if err != nil {
+		return "", err
+	}
+
+	activeMu.Lock()
+	active[filepath.Base(dir)] = true
+	activeMu.Unlock()
+
+	return dir, nil
+}
+
+func removeWorkspace(dir string) {
+	activeMu.Lock()
+	delete(active, filepath.Base(dir))
+	activeMu.Unlock()
+
+	if err := os.RemoveAll(dir); err != nil {
+		log.Warnf("removeWorkspace(): %v", err)
+	}
+}
+
+func isActive(name string) bool {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+
+	return active[name]
+}
+
+//sweepWorkspaces removes job directories left by a previous process, then keeps removing orphaned ones periodically.
+//Directories are left behind when the process crashes mid-render.
+func sweepWorkspaces() {
+	//nothing renders before startup is done, so every existing directory belongs to a previous process
+	removeWorkspaces(0)
+
+	go func() {
+		for {
+			time.Sleep(orphanAge)
+			removeWorkspaces(orphanAge)
+		}
+	}()
+}
+
+//removeWorkspaces removes job directories older than maxAge, except for the ones of running renders.
+//Modification time of a directory doesn't change when files are written to its subdirectories, so age alone can't tell if it's in use.
+func removeWorkspaces(maxAge time.Duration) {
+	files, err := ioutil.ReadDir(TempRoot)
+	if err != nil {
+		log.Warnf("removeWorkspaces(): %v", err)
+	}
+
+	removed := 0
+	for _, f := range files {
+		if time.Since(f.ModTime()) < maxAge || isActive(f.Name()) {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(TempRoot, f.Name())); err != nil {
+			log.Warnf("removeWorkspaces(): %v", err)
+			continue
+		}
+		removed++
+	}
+
+	if removed > 0 {
+		log.Infof("Removed %v orphaned ugoira job directories", removed)
+	}
+}
@@ -1,8 +1,12 @@
 package widget
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/VTGare/boe-tea-go/internal/dispatcher"
+	"github.com/VTGare/boe-tea-go/internal/locale"
+	"github.com/VTGare/boe-tea-go/internal/prompt"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -10,6 +14,12 @@ var (
 	controls = map[string]bool{"⏪": true, "⏹": true, "⏩": true}
 )
 
+const (
+	widgetPrevious = "widget:previous"
+	widgetStop     = "widget:stop"
+	widgetNext     = "widget:next"
+)
+
 //Widget is an interactive DiscordGo widget interface
 type Widget struct {
 	s           *discordgo.Session
@@ -17,19 +27,97 @@ type Widget struct {
 	author      string
 	currentPage int
 	Pages       []*discordgo.MessageEmbed
+	//nonce marks custom IDs of widget's buttons.
+	nonce string
+	//locales are preferred locales of widget's author, button labels are translated to them.
+	locales []string
 }
 
 func NewWidget(s *discordgo.Session, author string, embeds []*discordgo.MessageEmbed) *Widget {
-	return &Widget{s, nil, author, 0, embeds}
+	return &Widget{s, nil, author, 0, embeds, dispatcher.NewNonce(), nil}
 }
 
+//Start sends the first page with page buttons. Reaction controls are used if buttons couldn't be sent.
 func (w *Widget) Start(channelID string) error {
+	var guildID string
+	if ch, err := w.s.State.Channel(channelID); err == nil {
+		guildID = ch.GuildID
+	}
+	w.locales = dispatcher.Default.UserLocales(guildID, w.author)
+
+	components, cancel := dispatcher.Default.ComponentsByNonce(w.nonce)
+	defer cancel()
+
+	m, err := w.s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{Embed: w.Pages[0], Components: w.buttons(false)})
+	if err != nil {
+		return w.startReactions(channelID)
+	}
+	w.m = m
+
+	for {
+		i, ok := prompt.AwaitComponent(w.s, components, w.author, 2*time.Minute)
+		if !ok {
+			_, err := w.s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+				ID:         w.m.ID,
+				Channel:    w.m.ChannelID,
+				Embeds:     []*discordgo.MessageEmbed{w.Pages[w.currentPage]},
+				Components: w.buttons(true),
+			})
+			return err
+		}
+
+		stop := false
+		switch dispatcher.StripNonce(i.MessageComponentData().CustomID) {
+		case widgetPrevious:
+			if w.currentPage > 0 {
+				w.currentPage--
+			}
+		case widgetNext:
+			if w.currentPage < w.len()-1 {
+				w.currentPage++
+			}
+		case widgetStop:
+			stop = true
+		}
+
+		err := w.s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{w.Pages[w.currentPage]},
+				Components: w.buttons(stop),
+			},
+		})
+		if err != nil || stop {
+			return err
+		}
+	}
+}
+
+//buttons returns page controls. Closed widgets have no controls.
+func (w *Widget) buttons(closed bool) []discordgo.MessageComponent {
+	if closed {
+		return []discordgo.MessageComponent{}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.Button{Label: locale.Get(w.locales, "widget.previous"), Emoji: discordgo.ComponentEmoji{Name: "⏪"}, Style: discordgo.SecondaryButton, CustomID: dispatcher.WithNonce(w.nonce, widgetPrevious), Disabled: w.currentPage == 0},
+			discordgo.Button{Label: fmt.Sprintf("%v/%v", w.currentPage+1, w.len()), Emoji: discordgo.ComponentEmoji{Name: "⏹"}, Style: discordgo.DangerButton, CustomID: dispatcher.WithNonce(w.nonce, widgetStop)},
+			discordgo.Button{Label: locale.Get(w.locales, "widget.next"), Emoji: discordgo.ComponentEmoji{Name: "⏩"}, Style: discordgo.SecondaryButton, CustomID: dispatcher.WithNonce(w.nonce, widgetNext), Disabled: w.currentPage == w.len()-1},
+		}},
+	}
+}
+
+func (w *Widget) startReactions(channelID string) error {
 	m, err := w.s.ChannelMessageSendEmbed(channelID, w.Pages[0])
 	if err != nil {
 		return err
 	}
 	w.m = m
 
+	reactions, cancel := dispatcher.Default.Reactions(m.ID)
+	defer cancel()
+
 	w.s.MessageReactionAdd(m.ChannelID, m.ID, "⏪")
 	w.s.MessageReactionAdd(m.ChannelID, m.ID, "⏹")
 	w.s.MessageReactionAdd(m.ChannelID, m.ID, "⏩")
@@ -37,7 +125,7 @@ func (w *Widget) Start(channelID string) error {
 	var reaction *discordgo.MessageReaction
 	for {
 		select {
-		case k := <-nextMessageReactionAdd(w.s):
+		case k := <-reactions:
 			reaction = k.MessageReaction
 		case <-time.After(2 * time.Minute):
 			return nil
@@ -49,7 +137,7 @@ func (w *Widget) Start(channelID string) error {
 			continue
 		}
 
-		if reaction.MessageID != w.m.ID || w.s.State.User.ID == reaction.UserID || reaction.UserID != w.author {
+		if w.s.State.User.ID == reaction.UserID || reaction.UserID != w.author {
 			continue
 		}
 
@@ -104,11 +192,3 @@ func (w *Widget) pageDown() error {
 func (w *Widget) len() int {
 	return len(w.Pages)
 }
-
-func nextMessageReactionAdd(s *discordgo.Session) chan *discordgo.MessageReactionAdd {
-	out := make(chan *discordgo.MessageReactionAdd)
-	s.AddHandlerOnce(func(_ *discordgo.Session, e *discordgo.MessageReactionAdd) {
-		out <- e
-	})
-	return out
-}
@@ -0,0 +1,173 @@
+package ugoku
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	_ "image/jpeg"
+	"io"
+	"math"
+	"sort"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+var (
+	//ErrNoFrames is returned when an animation has no frames to encode.
+	ErrNoFrames = errors.New("ugoku: no frames to encode")
+)
+
+//Frame is a single frame of an animation. File is a file name inside the archive, Delay is in milliseconds.
+type Frame struct {
+	File  string
+	Delay int
+}
+
+//EncodeGIF reads JPEG frames from a zip archive and writes an infinitely looping GIF to w. Scale is applied to both dimensions and must be in (0, 1].
+func EncodeGIF(w io.Writer, archive string, frames []Frame, scale float64) error {
+	if len(frames) == 0 {
+		return ErrNoFrames
+	}
+
+	if scale <= 0 || scale > 1 {
+		return fmt.Errorf("ugoku: invalid scale %v", scale)
+	}
+
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	files := make(map[string]*zip.File)
+	for _, f := range r.File {
+		files[f.Name] = f
+	}
+
+	var (
+		anim   = &gif.GIF{LoopCount: 0}
+		delays = Delays(frames)
+	)
+
+	for ind, frame := range frames {
+		f, ok := files[frame.File]
+		if !ok {
+			return fmt.Errorf("ugoku: frame %v not found in archive", frame.File)
+		}
+
+		img, err := decode(f)
+		if err != nil {
+			return fmt.Errorf("ugoku: decoding %v: %v", frame.File, err)
+		}
+
+		if scale < 1 {
+			img = resize(img, scale)
+		}
+
+		anim.Image = append(anim.Image, quantize(img))
+		anim.Delay = append(anim.Delay, delays[ind])
+		anim.Disposal = append(anim.Disposal, gif.DisposalNone)
+	}
+
+	return gif.EncodeAll(w, anim)
+}
+
+//Delays converts frame delays from milliseconds to GIF's hundredths of a second. Rounding error is carried over to the next frame, so the total duration stays exact.
+func Delays(frames []Frame) []int {
+	var (
+		delays  = make([]int, len(frames))
+		elapsed = 0
+	)
+
+	for ind, frame := range frames {
+		start := int(math.Round(float64(elapsed) / 10))
+		elapsed += frame.Delay
+		end := int(math.Round(float64(elapsed) / 10))
+
+		delays[ind] = end - start
+	}
+
+	return delays
+}
+
+func decode(f *zip.File) (image.Image, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	img, _, err := image.Decode(rc)
+	return img, err
+}
+
+func resize(img image.Image, scale float64) image.Image {
+	b := img.Bounds()
+	width := int(math.Max(1, math.Round(float64(b.Dx())*scale)))
+	height := int(math.Max(1, math.Round(float64(b.Dy())*scale)))
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.ApproxBiLinear.Scale(dst, dst.Bounds(), img, b, xdraw.Src, nil)
+	return dst
+}
+
+//quantize converts an image to a paletted one using 256 most popular colours.
+func quantize(img image.Image) *image.Paletted {
+	b := img.Bounds()
+	dst := image.NewPaletted(image.Rect(0, 0, b.Dx(), b.Dy()), popularColors(img, 256))
+	draw.FloydSteinberg.Draw(dst, dst.Bounds(), img, b.Min)
+	return dst
+}
+
+//popularColors builds a palette from the most frequent colours reduced to 5 bits per channel.
+func popularColors(img image.Image, size int) color.Palette {
+	type bucket struct {
+		key   uint16
+		count int
+	}
+
+	var (
+		b      = img.Bounds()
+		counts = make(map[uint16]int)
+	)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, _ := img.At(x, y).RGBA()
+			key := uint16(r>>11)<<10 | uint16(g>>11)<<5 | uint16(bl>>11)
+			counts[key]++
+		}
+	}
+
+	buckets := make([]bucket, 0, len(counts))
+	for key, count := range counts {
+		buckets = append(buckets, bucket{key, count})
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].count == buckets[j].count {
+			return buckets[i].key < buckets[j].key
+		}
+		return buckets[i].count > buckets[j].count
+	})
+
+	if len(buckets) > size {
+		buckets = buckets[:size]
+	}
+
+	palette := make(color.Palette, 0, len(buckets))
+	for _, bk := range buckets {
+		palette = append(palette, color.RGBA{
+			R: uint8(bk.key>>10&0x1f)<<3 | 0x4,
+			G: uint8(bk.key>>5&0x1f)<<3 | 0x4,
+			B: uint8(bk.key&0x1f)<<3 | 0x4,
+			A: 0xff,
+		})
+	}
+
+	return palette
+}
@@ -0,0 +1,93 @@
+package ugoku
+
+import (
+	"bytes"
+	"image/gif"
+	"reflect"
+	"testing"
+)
+
+var (
+	sample       = "testdata/sample.zip"
+	sampleFrames = []Frame{
+		{"000000.jpg", 100},
+		{"000001.jpg", 60},
+		{"000002.jpg", 125},
+		{"000003.jpg", 35},
+	}
+)
+
+func TestDelays(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []Frame
+		want   []int
+	}{
+		{"whole", []Frame{{"", 100}, {"", 60}}, []int{10, 6}},
+		{"carry over", []Frame{{"", 15}, {"", 15}, {"", 15}, {"", 15}}, []int{2, 1, 2, 1}},
+		{"sample", sampleFrames, []int{10, 6, 13, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Delays(tt.frames); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Delays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeGIF(t *testing.T) {
+	tests := []struct {
+		name   string
+		scale  float64
+		width  int
+		height int
+	}{
+		{"original", 1, 32, 24},
+		{"half", 0.5, 16, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := EncodeGIF(&buf, sample, sampleFrames, tt.scale); err != nil {
+				t.Fatalf("EncodeGIF(): %v", err)
+			}
+
+			res, err := gif.DecodeAll(&buf)
+			if err != nil {
+				t.Fatalf("gif.DecodeAll(): %v", err)
+			}
+
+			switch {
+			case len(res.Image) != len(sampleFrames):
+				t.Errorf("Frames mismatch. Expected %v, got %v", len(sampleFrames), len(res.Image))
+			case !reflect.DeepEqual(res.Delay, Delays(sampleFrames)):
+				t.Errorf("Delays mismatch. Expected %v, got %v", Delays(sampleFrames), res.Delay)
+			case res.LoopCount != 0:
+				t.Errorf("LoopCount mismatch. Expected 0, got %v", res.LoopCount)
+			case res.Config.Width != tt.width || res.Config.Height != tt.height:
+				t.Errorf("Size mismatch. Expected %vx%v, got %vx%v", tt.width, tt.height, res.Config.Width, res.Config.Height)
+			}
+		})
+	}
+}
+
+func TestEncodeGIFErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []Frame
+		scale  float64
+	}{
+		{"no frames", []Frame{}, 1},
+		{"missing frame", []Frame{{"000009.jpg", 100}}, 1},
+		{"invalid scale", sampleFrames, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := EncodeGIF(&buf, sample, tt.frames, tt.scale); err == nil {
+				t.Errorf("EncodeGIF() expected an error")
+			}
+		})
+	}
+}
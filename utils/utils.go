@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
-	log "github.com/sirupsen/logrus"
 )
 
 //Range is a range struct. Low is beginning value and High is end value. High can't be higher than Low.
@@ -19,13 +18,6 @@ type Range struct {
 	High int
 }
 
-//PromptOptions is a struct that defines prompt's behaviour.
-type PromptOptions struct {
-	Actions map[string]bool
-	Message string
-	Timeout time.Duration
-}
-
 var (
 	IsPixivUp bool
 	//DefaultEmbedImage is an image for embeds
@@ -83,8 +75,29 @@ func Filter(vs []string, f func(string) bool) []string {
 	return vsm
 }
 
-//MemberHasPermission checks if guild member has a permission to do something on a server.
-func MemberHasPermission(s *discordgo.Session, guildID string, userID string, permission int) (bool, error) {
+func Contains(vs []string, str string) bool {
+	for _, v := range vs {
+		if v == str {
+			return true
+		}
+	}
+	return false
+}
+
+//MemberHasPermission checks if guild member has any of given permissions on a server.
+//Channel permission overwrites are applied if channelID is not empty.
+func MemberHasPermission(s *discordgo.Session, guildID, channelID, userID string, permission int64) (bool, error) {
+	if channelID != "" {
+		perms, err := s.State.UserChannelPermissions(userID, channelID)
+		if err != nil {
+			if perms, err = s.UserChannelPermissions(userID, channelID); err != nil {
+				return false, err
+			}
+		}
+
+		return perms&permission != 0, nil
+	}
+
 	member, err := s.State.Member(guildID, userID)
 	if err != nil {
 		if member, err = s.GuildMember(guildID, userID); err != nil {
@@ -99,9 +112,9 @@ func MemberHasPermission(s *discordgo.Session, guildID string, userID string, pe
 	if g.OwnerID == userID {
 		return true, nil
 	}
-	// Iterate through the role IDs stored in member.Roles
+	// Iterate through the role IDs stored in member.Roles and @everyone role
 	// to check permissions
-	for _, roleID := range member.Roles {
+	for _, roleID := range append([]string{guildID}, member.Roles...) {
 		role, err := s.State.Role(guildID, roleID)
 		if err != nil {
 			return false, err
@@ -114,131 +127,116 @@ func MemberHasPermission(s *discordgo.Session, guildID string, userID string, pe
 	return false, nil
 }
 
-//NewRange creates a new Range struct from a string. Correct format for a string is first integer-last integer (higher than first)
-func NewRange(s string) (*Range, error) {
-	hyphen := strings.IndexByte(s, '-')
-	if hyphen == -1 {
-		return nil, errors.New("not a range")
+//IsBotManager checks if a member can configure Boe Tea: server administrators and members with one of bot manager roles.
+func IsBotManager(s *discordgo.Session, guildID, channelID, userID string, managerRoles []string) (bool, error) {
+	isAdmin, err := MemberHasPermission(s, guildID, channelID, userID, discordgo.PermissionAdministrator)
+	if err != nil || isAdmin {
+		return isAdmin, err
 	}
-	lowStr := s[:hyphen]
-	highStr := s[hyphen+1:]
 
-	low, err := strconv.Atoi(lowStr)
-	if err != nil {
-		return nil, ErrParsingArgument
+	if len(managerRoles) == 0 {
+		return false, nil
 	}
 
-	high, err := strconv.Atoi(highStr)
+	member, err := s.State.Member(guildID, userID)
 	if err != nil {
-		return nil, ErrParsingArgument
+		if member, err = s.GuildMember(guildID, userID); err != nil {
+			return false, err
+		}
 	}
 
-	if low > high {
-		return nil, errors.New("low is higher than high")
+	for _, role := range member.Roles {
+		for _, manager := range managerRoles {
+			if role == manager {
+				return true, nil
+			}
+		}
 	}
 
-	return &Range{
-		Low:  low,
-		High: high,
-	}, nil
+	return false, nil
 }
 
-//EmbedTimestamp returns currect time formatted to RFC3339 for Discord Embeds
-func EmbedTimestamp() string {
-	return time.Now().Format(time.RFC3339)
+var permissionNames = []struct {
+	permission int64
+	name       string
+}{
+	{discordgo.PermissionViewChannel, "View Channel"},
+	{discordgo.PermissionSendMessages, "Send Messages"},
+	{discordgo.PermissionEmbedLinks, "Embed Links"},
+	{discordgo.PermissionAttachFiles, "Attach Files"},
+	{discordgo.PermissionManageWebhooks, "Manage Webhooks"},
 }
 
-//CreatePrompt sends a prompt message to a discord channel
-func CreatePrompt(s *discordgo.Session, m *discordgo.MessageCreate, opts *PromptOptions) bool {
-	prompt, err := s.ChannelMessageSend(m.ChannelID, opts.Message)
+//MissingChannelPermissions returns names of permissions a user lacks in a channel. Only permissions with known names are checked.
+func MissingChannelPermissions(s *discordgo.Session, userID, channelID string, permissions int64) ([]string, error) {
+	perms, err := s.UserChannelPermissions(userID, channelID)
 	if err != nil {
-		log.Warnln(err)
-		return false
+		return nil, err
 	}
 
-	for emoji := range opts.Actions {
-		err = s.MessageReactionAdd(m.ChannelID, prompt.ID, emoji)
-		if err != nil {
-			log.Warnln(err)
-			return false
+	missing := make([]string, 0)
+	for _, p := range permissionNames {
+		if permissions&p.permission != 0 && perms&p.permission == 0 {
+			missing = append(missing, p.name)
 		}
 	}
 
-	var reaction *discordgo.MessageReaction
-	for {
-		select {
-		case k := <-nextMessageReactionAdd(s):
-			reaction = k.MessageReaction
-		case <-time.After(opts.Timeout):
-			s.ChannelMessageDelete(prompt.ChannelID, prompt.ID)
-			return false
-		}
-
-		if _, ok := opts.Actions[reaction.Emoji.APIName()]; !ok {
-			continue
-		}
+	return missing, nil
+}
 
-		if reaction.MessageID != prompt.ID || s.State.User.ID == reaction.UserID || reaction.UserID != m.Author.ID {
-			continue
-		}
+//CanCrosspost checks if a user can view and send messages in a channel, and if Boe Tea can send embeds and files there.
+func CanCrosspost(s *discordgo.Session, userID, channelID string) error {
+	missing, err := MissingChannelPermissions(s, userID, channelID, discordgo.PermissionViewChannel|discordgo.PermissionSendMessages)
+	if err != nil {
+		return errors.New("unable to verify your permissions, make sure you're a member of channel's server")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("you're missing %v permissions", strings.Join(missing, ", "))
+	}
 
-		s.ChannelMessageDelete(prompt.ChannelID, prompt.ID)
-		return opts.Actions[reaction.Emoji.APIName()]
+	missing, err = MissingChannelPermissions(s, s.State.User.ID, channelID, discordgo.PermissionViewChannel|discordgo.PermissionSendMessages|discordgo.PermissionEmbedLinks|discordgo.PermissionAttachFiles)
+	if err != nil {
+		return errors.New("unable to verify Boe Tea's permissions")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("Boe Tea is missing %v permissions", strings.Join(missing, ", "))
 	}
+
+	return nil
 }
 
-//CreatePromptWithMessage sends a prompt message to a discord channel
-func CreatePromptWithMessage(s *discordgo.Session, m *discordgo.MessageCreate, message *discordgo.MessageSend) bool {
-	var (
-		timeout = 45 * time.Second
-		actions = map[string]bool{"👌": true, "🙅‍♂️": false}
-	)
-
-	prompt, err := s.ChannelMessageSendComplex(m.ChannelID, message)
-	if err != nil || prompt == nil {
-		log.Warnln(err)
-		s.ChannelMessageSend(m.ChannelID, "Error while creating a prompt")
-		return false
+//NewRange creates a new Range struct from a string. Correct format for a string is first integer-last integer (higher than first)
+func NewRange(s string) (*Range, error) {
+	hyphen := strings.IndexByte(s, '-')
+	if hyphen == -1 {
+		return nil, errors.New("not a range")
 	}
+	lowStr := s[:hyphen]
+	highStr := s[hyphen+1:]
 
-	for emoji := range actions {
-		err = s.MessageReactionAdd(m.ChannelID, prompt.ID, emoji)
-		if err != nil {
-			log.Warnln(err)
-			s.ChannelMessageSend(m.ChannelID, "Error while creating a prompt")
-			return false
-		}
+	low, err := strconv.Atoi(lowStr)
+	if err != nil {
+		return nil, ErrParsingArgument
 	}
 
-	var reaction *discordgo.MessageReaction
-	for {
-		select {
-		case k := <-nextMessageReactionAdd(s):
-			reaction = k.MessageReaction
-		case <-time.After(timeout):
-			s.ChannelMessageDelete(prompt.ChannelID, prompt.ID)
-			return false
-		}
-
-		if _, ok := actions[reaction.Emoji.APIName()]; !ok {
-			continue
-		}
-
-		if reaction.MessageID != prompt.ID || s.State.User.ID == reaction.UserID || reaction.UserID != m.Author.ID {
-			continue
-		}
+	high, err := strconv.Atoi(highStr)
+	if err != nil {
+		return nil, ErrParsingArgument
+	}
 
-		s.ChannelMessageDelete(prompt.ChannelID, prompt.ID)
-		return actions[reaction.Emoji.APIName()]
+	if low > high {
+		return nil, errors.New("low is higher than high")
 	}
+
+	return &Range{
+		Low:  low,
+		High: high,
+	}, nil
 }
 
-func nextMessageReactionAdd(s *discordgo.Session) chan *discordgo.MessageReactionAdd {
-	out := make(chan *discordgo.MessageReactionAdd)
-	s.AddHandlerOnce(func(_ *discordgo.Session, e *discordgo.MessageReactionAdd) {
-		out <- e
-	})
-	return out
+//EmbedTimestamp returns currect time formatted to RFC3339 for Discord Embeds
+func EmbedTimestamp() string {
+	return time.Now().Format(time.RFC3339)
 }
 
 //FormatBool returns human-readable representation of boolean